@@ -0,0 +1,251 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// AsyncValidator is a validator which may block, eg: check the username is not
+// taken, the SKU exists. the implementations must be safe for concurrent use.
+//
+// It is registered by AddAsyncValidator, the engine runs it concurrently after
+// the synchronous rules, with the worker limit Validation.AsyncLimit.
+type AsyncValidator interface {
+	// CheckAsync check the field value. return false on validate failed.
+	// a non-nil err means the check could not be done, it is reported by the
+	// message "_async", the err is the message arg. the timeout is reported by
+	// the message "_asyncTimeout".
+	CheckAsync(ctx context.Context, field string, val any, args []any) (ok bool, err error)
+}
+
+// AsyncFunc the func adapter of the AsyncValidator
+//
+// Usage:
+//
+//	validate.AddAsyncValidator("unique", validate.AsyncFunc(func(ctx context.Context, field string, val any, args []any) (bool, error) {
+//		return repo.NotExists(ctx, args[0].(string), val)
+//	}), time.Second)
+type AsyncFunc func(ctx context.Context, field string, val any, args []any) (bool, error)
+
+// CheckAsync implements the AsyncValidator
+func (fn AsyncFunc) CheckAsync(ctx context.Context, field string, val any, args []any) (bool, error) {
+	return fn(ctx, field, val, args)
+}
+
+// asyncMeta the registered async validator
+type asyncMeta struct {
+	av AsyncValidator
+	// timeout for each call. 0 = no timeout, only the validation context.
+	timeout time.Duration
+}
+
+// asyncJob a queued async validate of the field
+type asyncJob struct {
+	rule  *Rule
+	fm    *funcMeta
+	field string
+	val   any
+	args  []any
+	// the wildcard field of the element job. eg: "Items.*.Sku"
+	wildcard string
+	// result
+	ok  bool
+	err error
+}
+
+func newAsyncMeta(name string, av AsyncValidator, timeout time.Duration) *funcMeta {
+	if !goodName(name) {
+		panicf("validate name %s is not a valid identifier", name)
+	}
+	if av == nil {
+		panicf("async validator '%s' is nil", name)
+	}
+
+	fm := newFuncMeta(name, false, reflect.ValueOf(av.CheckAsync))
+	fm.style = styleAsync
+	fm.async = &asyncMeta{av: av, timeout: timeout}
+	return fm
+}
+
+// AddAsyncValidator add an async validator to the pkg.
+// timeout is the limit of each call, 0 = no timeout.
+func AddAsyncValidator(name string, av AsyncValidator, timeout time.Duration) {
+	validators[name] = validatorTypeCustom
+	validatorMetas[name] = newAsyncMeta(name, av, timeout)
+}
+
+// AddAsyncValidator add an async validator to the Validation instance.
+// timeout is the limit of each call, 0 = no timeout.
+func (v *Validation) AddAsyncValidator(name string, av AsyncValidator, timeout time.Duration) *Validation {
+	v.ensureValidatorMaps() // lazy
+	v.validators[name] = validatorTypeCustom
+	v.validatorMetas[name] = newAsyncMeta(name, av, timeout)
+	return v
+}
+
+// queueAsync queue the async validate of the field, run it by runAsync.
+// wildcard is the rule field of the wildcard element, empty for others.
+func (r *Rule) queueAsync(fm *funcMeta, field, wildcard string, val any, v *Validation) {
+	v.asyncJobs = append(v.asyncJobs, &asyncJob{
+		rule:     r,
+		fm:       fm,
+		field:    field,
+		val:      val,
+		args:     r.errorArgs(),
+		wildcard: wildcard,
+	})
+}
+
+// queueAsyncElems queue the async validate of each element of the ".*" wildcard
+// slice, the element path is passed to CheckAsync. eg: "Items.*.Sku" -> "Items.1.Sku"
+func (r *Rule) queueAsyncElems(fm *funcMeta, field string, rv reflect.Value, dotStarNum int, v *Validation) {
+	if dotStarNum > 1 {
+		rv = flatSlice(rv, dotStarNum-1)
+	}
+
+	paths := wildcardElemPaths(field, rv, v)
+	eachElem(rv, func(i int, ev reflect.Value) bool {
+		var val any
+		if ev.IsValid() {
+			val = ev.Interface()
+		}
+
+		path := field
+		if paths != nil {
+			path = paths[i]
+		}
+		r.queueAsync(fm, path, field, val, v)
+		return true
+	})
+}
+
+// runAsync run the queued async validators concurrently, then merge the
+// results in the queued order. the fields already have an error are skipped.
+func (v *Validation) runAsync() {
+	jobs := v.asyncJobs[:0]
+	for _, job := range v.asyncJobs {
+		if v.fieldFailed(job.field) || job.wildcard != "" && v.fieldFailed(job.wildcard) {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	limit := v.AsyncLimit
+	if limit <= 0 || limit > len(jobs) {
+		limit = len(jobs)
+	}
+
+	ctx := v.Context()
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job *asyncJob) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			job.run(ctx)
+		}(job)
+	}
+	wg.Wait()
+
+	// the validation context is done
+	if v.ctxDone() {
+		return
+	}
+
+	for _, job := range jobs {
+		if job.ok {
+			continue
+		}
+
+		// the wildcard element error is reported on the rule field same as the
+		// sync validators, only the first failed element. eg: "Items.*.Sku"
+		field := job.field
+		if job.wildcard != "" {
+			if v.fieldFailed(job.wildcard) {
+				continue
+			}
+			field = job.wildcard
+		}
+
+		fe := FieldError{
+			Field:     field,
+			Path:      v.outputPath(job.field),
+			Validator: job.fm.name,
+			Alias:     job.rule.validator,
+			Args:      job.args,
+			Value:     job.val,
+		}
+
+		switch {
+		case errors.Is(job.err, ErrAsyncTimeout):
+			fe.Message = v.trans.Message(asyncTimeoutError, field)
+		case job.err != nil:
+			fe.Message = v.trans.Message(asyncError, field, job.err)
+		default:
+			fe.Message = job.rule.errorMessage(field, job.rule.validator, v)
+		}
+
+		v.addFieldError(fe)
+		if v.shouldStop() {
+			return
+		}
+	}
+}
+
+// run the async validator. on the timeout or the ctx done, returns without
+// waiting for the validator, even if it ignores the ctx.
+func (job *asyncJob) run(ctx context.Context) {
+	am := job.fm.async
+	if am.timeout <= 0 && ctx.Done() == nil {
+		job.ok, job.err = am.av.CheckAsync(ctx, job.field, job.val, job.args)
+		return
+	}
+
+	var cancel context.CancelFunc
+	if am.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, am.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	type result struct {
+		ok  bool
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		ok, err := am.av.CheckAsync(ctx, job.field, job.val, job.args)
+		ch <- result{ok, err}
+	}()
+
+	select {
+	case res := <-ch:
+		job.ok, job.err = res.ok, res.err
+	case <-ctx.Done():
+		job.err = ctx.Err()
+	}
+	if errors.Is(job.err, context.DeadlineExceeded) && am.timeout > 0 {
+		job.err = ErrAsyncTimeout
+	}
+}
+
+// fieldFailed check the field has an error.
+func (v *Validation) fieldFailed(field string) bool {
+	for _, fe := range v.FieldErrors() {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
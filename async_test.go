@@ -0,0 +1,219 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+// fakeLookup in-process fake of a lookup store. eg: database
+type fakeLookup struct {
+	taken map[string]bool
+	delay time.Duration
+	err   error
+
+	mu      sync.Mutex
+	running int
+	peak    int
+	calls   atomic.Int32
+}
+
+func (f *fakeLookup) CheckAsync(ctx context.Context, field string, val any, args []any) (bool, error) {
+	f.calls.Add(1)
+	f.mu.Lock()
+	f.running++
+	if f.running > f.peak {
+		f.peak = f.running
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+	if f.err != nil {
+		return false, f.err
+	}
+
+	s, _ := val.(string)
+	return !f.taken[s], nil
+}
+
+func TestValidation_AddAsyncValidator(t *testing.T) {
+	is := assert.New(t)
+
+	fake := &fakeLookup{taken: map[string]bool{"tom": true, "sku1": true}, delay: 20 * time.Millisecond}
+	data := M{"name": "tom", "sku": "sku1", "code": "c1", "nick": "n1", "email": "bad"}
+
+	v := New(data)
+	v.StopOnError = false
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("name", "required|unique")
+	v.StringRule("sku", "unique")
+	v.StringRule("code", "unique")
+	v.StringRule("nick", "unique")
+	v.StringRule("email", "email|unique")
+	v.AddMessages(map[string]string{"unique": "{field} is already taken"})
+
+	start := time.Now()
+	is.False(v.Validate())
+	// run concurrently, not the sum of the lookups
+	is.True(time.Since(start) < 60*time.Millisecond)
+	// email failed on the sync rule, async is skipped
+	is.Eq(int32(4), fake.calls.Load())
+
+	// merged in the rule order
+	fes := v.FieldErrors()
+	is.Len(fes, 3)
+	is.Eq("email", fes[0].Field)
+	is.Eq("name", fes[1].Field)
+	is.Eq("unique", fes[1].Validator)
+	is.Eq("name is already taken", fes[1].Message)
+	is.Eq("sku", fes[2].Field)
+	is.False(v.Errors.HasField("code"))
+	is.Empty(v.safeData)
+
+	// worker limit
+	fake = &fakeLookup{delay: 5 * time.Millisecond}
+	v = New(data)
+	v.AsyncLimit = 2
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("name,sku,code,nick", "unique")
+	is.True(v.Validate())
+	is.Eq(2, fake.peak)
+	is.Eq("tom", v.safeData["name"])
+
+	// stop on error: first in the rule order
+	fake = &fakeLookup{taken: map[string]bool{"sku1": true, "n1": true}}
+	v = New(data)
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("name,sku,code,nick", "unique")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.True(v.Errors.HasField("sku"))
+}
+
+func TestAsyncValidator_timeout(t *testing.T) {
+	is := assert.New(t)
+
+	// ignores the ctx, still timeout
+	slow := AsyncFunc(func(ctx context.Context, field string, val any, args []any) (bool, error) {
+		time.Sleep(200 * time.Millisecond)
+		return true, nil
+	})
+
+	v := New(M{"name": "tom"})
+	v.AddAsyncValidator("slowUnique", slow, 10*time.Millisecond)
+	v.StringRule("name", "slowUnique")
+	start := time.Now()
+	is.False(v.Validate())
+	is.True(time.Since(start) < 100*time.Millisecond)
+	is.Eq("name validation timed out", v.Errors.One())
+
+	// lookup error as the field error
+	v = New(M{"name": "tom"})
+	v.AddAsyncValidator("unique", &fakeLookup{err: errors.New("db is down")}, time.Second)
+	v.StringRule("name", "unique")
+	is.False(v.Validate())
+	is.Eq("name could not be validated: db is down", v.Errors.FieldOne("name"))
+
+	// the messages are translated
+	v = New(M{"name": "tom"})
+	v.AddAsyncValidator("unique", &fakeLookup{err: errors.New("db is down")}, time.Second)
+	v.StringRule("name", "unique")
+	v.AddTranslates(map[string]string{"name": "User Name"})
+	v.AddMessages(map[string]string{"name._async": "{field} check failed, try later"})
+	is.False(v.Validate())
+	is.Eq("User Name check failed, try later", v.Errors.FieldOne("name"))
+
+	// canceled context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	v = New(M{"name": "tom"})
+	v.AddAsyncValidator("unique", &fakeLookup{delay: time.Second}, 0)
+	v.StringRule("name", "unique")
+	is.False(v.ValidateCtx(ctx))
+	is.True(errors.Is(v.CtxErr(), context.DeadlineExceeded))
+	is.True(v.Errors.HasField(contextError))
+}
+
+func TestAddAsyncValidator_global(t *testing.T) {
+	is := assert.New(t)
+
+	AddAsyncValidator("asyncNotTaken", &fakeLookup{taken: map[string]bool{"tom": true}}, time.Second)
+	defer func() {
+		delete(validators, "asyncNotTaken")
+		delete(validatorMetas, "asyncNotTaken")
+	}()
+
+	is.NoErr(Val("jerry", "required|asyncNotTaken"))
+	is.Err(Val("tom", "required|asyncNotTaken"))
+
+	type user struct {
+		Name string `validate:"required|asyncNotTaken"`
+	}
+	is.True(Check(&user{Name: "jerry"}).IsOK())
+	r := Check(&user{Name: "tom"})
+	is.True(r.Fail())
+	is.Eq("Name", r.FieldErrors()[0].Field)
+}
+
+func TestAsyncValidator_wildcard(t *testing.T) {
+	is := assert.New(t)
+
+	fake := &fakeLookup{taken: map[string]bool{"sku2": true}}
+	v := Map(M{"items": []any{map[string]any{"sku": "sku1"}, map[string]any{"sku": "sku2"}, map[string]any{"sku": "sku3"}}})
+	v.StopOnError = false
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("items.*.sku", "unique")
+	is.False(v.Validate())
+
+	// one job per element
+	is.Eq(int32(3), fake.calls.Load())
+	fes := v.FieldErrors()
+	is.Len(fes, 1)
+	is.Eq("items.*.sku", fes[0].Field)
+	is.Eq("sku2", fes[0].Value)
+	is.Eq("items.*.sku field did not pass validation", fes[0].Message)
+
+	// skipped on the sync rule failed
+	fake = &fakeLookup{}
+	v = Map(M{"items": []any{map[string]any{"sku": "sku1"}, map[string]any{"sku": "s"}}})
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("items.*.sku", "minLen:3|unique")
+	is.False(v.Validate())
+	is.Eq(int32(0), fake.calls.Load())
+}
+
+func TestAsyncValidator_ruleExpr(t *testing.T) {
+	is := assert.New(t)
+
+	fake := &fakeLookup{}
+	v := Map(M{"name": "tom"})
+	v.CollectConfigErr = true
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("name", "email or unique")
+	is.False(v.Validate())
+	is.Eq(int32(0), fake.calls.Load())
+
+	err := v.ValidateErr()
+	is.ErrIs(err, ErrConfig)
+	is.StrContains(err.Error(), "the async validator 'unique' can not be used in the rule expression")
+
+	// check on precompile
+	v = Map(M{"name": "tom"})
+	v.AddAsyncValidator("unique", fake, 0)
+	v.StringRule("name", "not unique")
+	is.ErrIs(v.Precompile(), ErrConfig)
+}
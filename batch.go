@@ -0,0 +1,227 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gookit/goutil/errorx"
+)
+
+// BatchOption settings for the CheckAll
+type BatchOption struct {
+	// Scene the validate scene name
+	Scene string
+	// Workers the number of goroutines to validate the elements.
+	// <= 1 validate the elements in the current goroutine. default: 1
+	Workers int
+	// MaxFailures stop after N failing elements. <= 0 is no limit.
+	//
+	// The result is the same as validate in order, even if Workers > 1: the
+	// elements after the Nth failing element are not reported.
+	MaxFailures int
+}
+
+// BatchResult the result of the CheckAll, indexed by element.
+type BatchResult struct {
+	// Results the result of each element, nil for the element not validated
+	// (stopped by BatchOption.MaxFailures)
+	Results []*ValidResult
+	// Errors the combined errors of all elements, keyed by "[i].field".
+	// eg: "[0].Name", "[3].Items.1.Sku"
+	Errors Errors
+	// Failed the indexes of the failed elements, in ascending order
+	Failed []int
+	// Stopped reports the validation is stopped by BatchOption.MaxFailures
+	Stopped bool
+
+	// the combined errors with details, in the element order
+	fieldErrs fieldErrorList
+	// the bad input error, wraps the ErrInvalidData
+	err error
+}
+
+// IsOK reports whether all elements passed.
+func (br *BatchResult) IsOK() bool { return !br.Fail() }
+
+// Fail reports whether any element failed, or the input is invalid.
+func (br *BatchResult) Fail() bool { return br.err != nil || len(br.Failed) > 0 }
+
+// Err returns the first error of the first failed element, otherwise nil.
+// On the input is not a slice or array, returns an error wraps ErrInvalidData.
+func (br *BatchResult) Err() error {
+	if br.err != nil {
+		return br.err
+	}
+	if fes := br.FieldErrors(); len(fes) > 0 {
+		return errorx.Raw(fes[0].Message)
+	}
+	return nil
+}
+
+// FieldErrors returns the combined errors with details, in the element order.
+// the Field and Name are prefixed with "[i].".
+func (br *BatchResult) FieldErrors() []FieldError {
+	if len(br.fieldErrs.items) > 0 {
+		return br.fieldErrs.items
+	}
+	// the errors added to the Errors map directly
+	return br.Errors.List()
+}
+
+// addFieldError add the error to the Errors and the details.
+func (br *BatchResult) addFieldError(fe FieldError) {
+	br.Errors.Add(fe.Name, fe.Alias, fe.Message)
+	br.fieldErrs.add(fe)
+}
+
+// CheckAll validate a slice(or array) of structs by the package pool.
+// see Factory.CheckAll
+//
+// Usage:
+//
+//	br := validate.CheckAll(orders, func(opt *validate.BatchOption) {
+//		opt.Workers = 4
+//		opt.MaxFailures = 100
+//	})
+//	if br.Fail() {
+//		fmt.Println(br.Errors) // "[3].Email": ...
+//	}
+func CheckAll(slice any, fns ...func(opt *BatchOption)) *BatchResult {
+	return defaultFactory.CheckAll(slice, fns...)
+}
+
+// CheckAll validate each element of a slice(or array) of structs, the elements
+// can be struct or pointer to struct. The pooled instances, the cached type
+// metadata and rule template are shared across the elements.
+//
+// Use a pointer to the slice/array, or a slice of the pointers, to write back
+// the default and filtered values. The other input types, and the nil elements
+// fail with the error ErrInvalidData.
+func (f *Factory) CheckAll(slice any, fns ...func(opt *BatchOption)) *BatchResult {
+	opt := &BatchOption{Workers: 1}
+	for _, fn := range fns {
+		fn(opt)
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(slice))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		br := &BatchResult{Errors: make(Errors)}
+		br.err = fmt.Errorf("%w: CheckAll the input must be a slice or array, but got %T", ErrInvalidData, slice)
+		br.addFieldError(FieldError{
+			Field:     validateError,
+			Name:      validateError,
+			Validator: validateError,
+			Alias:     validateError,
+			Message:   br.err.Error(),
+		})
+		return br
+	}
+
+	n := rv.Len()
+	br := &BatchResult{Results: make([]*ValidResult, n), Errors: make(Errors)}
+	if n == 0 {
+		return br
+	}
+
+	// the number of the failed elements, for the MaxFailures
+	var failures atomic.Int32
+	checkElem := func(i int) {
+		// the nil pointer or interface element is reported as ErrInvalidData
+		var elem any
+		switch ev := rv.Index(i); {
+		case (ev.Kind() == reflect.Ptr || ev.Kind() == reflect.Interface) && ev.IsNil():
+		case ev.CanAddr() && ev.Kind() == reflect.Struct:
+			elem = ev.Addr().Interface()
+		default:
+			elem = ev.Interface()
+		}
+
+		r := f.Struct(elem, opt.Scene).ValidateR()
+		br.Results[i] = r
+		if r.Fail() {
+			failures.Add(1)
+		}
+	}
+	stopped := func() bool {
+		return opt.MaxFailures > 0 && int(failures.Load()) >= opt.MaxFailures
+	}
+
+	workers := opt.Workers
+	if workers > n {
+		workers = n
+	}
+
+	if workers <= 1 {
+		for i := 0; i < n && !stopped(); i++ {
+			checkElem(i)
+		}
+	} else {
+		var next atomic.Int32
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for !stopped() {
+					i := int(next.Add(1)) - 1
+					if i >= n {
+						return
+					}
+					checkElem(i)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	br.merge(opt.MaxFailures)
+	return br
+}
+
+// merge the element results in order. drop the results after the Nth failed
+// element, so the concurrent result is the same as in order.
+func (br *BatchResult) merge(maxFailures int) {
+	for i, r := range br.Results {
+		if r == nil {
+			continue
+		}
+		if maxFailures > 0 && len(br.Failed) >= maxFailures {
+			br.Results[i] = nil
+			br.Stopped = true
+			continue
+		}
+		if r.IsOK() {
+			continue
+		}
+
+		br.Failed = append(br.Failed, i)
+		prefix := "[" + strconv.Itoa(i) + "]"
+		for _, fe := range r.FieldErrors() {
+			fe.Field = batchErrKey(prefix, fe.Field)
+			fe.Name = batchErrKey(prefix, fe.Name)
+			br.addFieldError(fe)
+		}
+	}
+
+	// not all elements validated
+	if !br.Stopped && maxFailures > 0 && len(br.Failed) >= maxFailures {
+		for _, r := range br.Results {
+			if r == nil {
+				br.Stopped = true
+				break
+			}
+		}
+	}
+}
+
+// batchErrKey build the error key of the element. eg: "[0].Name", "[0]"
+func batchErrKey(prefix, field string) string {
+	if field == "" || strings.HasPrefix(field, "_") {
+		return prefix
+	}
+	return prefix + "." + field
+}
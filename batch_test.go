@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type batchOrder struct {
+	ID    int    `validate:"required|min:1"`
+	Email string `validate:"required|email"`
+	Note  string `validate:"maxLen:5" filter:"trim"`
+}
+
+func batchOrders(n int, bad ...int) []batchOrder {
+	list := make([]batchOrder, n)
+	for i := range list {
+		list[i] = batchOrder{ID: i + 1, Email: fmt.Sprintf("u%d@example.com", i), Note: " ok "}
+	}
+	for _, i := range bad {
+		list[i].Email = "bad"
+	}
+	return list
+}
+
+func TestCheckAll(t *testing.T) {
+	is := assert.New(t)
+
+	list := batchOrders(10, 2, 5, 7)
+	br := CheckAll(list)
+	is.True(br.Fail())
+	is.False(br.Stopped)
+	is.Eq([]int{2, 5, 7}, br.Failed)
+	is.Len(br.Results, 10)
+	is.True(br.Results[0].IsOK())
+	is.True(br.Results[2].Fail())
+	is.Len(br.Errors, 3)
+	is.True(br.Errors.HasField("[2].Email"))
+	is.Eq("[2].Email", br.FieldErrors()[0].Field)
+	is.Eq("Email value is an invalid email address", br.Err().Error())
+	// write back the filtered value
+	is.Eq("ok", list[0].Note)
+
+	// pointer elements, no error
+	ptrs := []*batchOrder{{ID: 1, Email: "a@example.com"}, {ID: 2, Email: "b@example.com"}}
+	br = CheckAll(&ptrs)
+	is.True(br.IsOK())
+	is.NoErr(br.Err())
+	is.Len(br.Results, 2)
+
+	// empty
+	is.True(CheckAll([]batchOrder{}).IsOK())
+
+	// the nil elements
+	br = CheckAll([]*batchOrder{{ID: 1, Email: "a@example.com"}, nil})
+	is.Eq([]int{1}, br.Failed)
+	is.True(br.Results[0].IsOK())
+	is.Eq("invalid input data", br.Errors.FieldOne("[1]"))
+	is.ErrMsg(br.Err(), "invalid input data")
+	br = CheckAll([]any{nil, &batchOrder{ID: 1, Email: "a@example.com"}})
+	is.Eq([]int{0}, br.Failed)
+	is.True(Struct((*batchOrder)(nil)).ValidateE().HasField(validateError))
+
+	// bad input type
+	br = CheckAll(batchOrder{})
+	is.True(br.Fail())
+	is.ErrIs(br.Err(), ErrInvalidData)
+	is.ErrMsg(br.Err(), "invalid input data: CheckAll the input must be a slice or array, but got validate.batchOrder")
+	is.True(br.Errors.HasField(validateError))
+	is.Empty(br.Results)
+}
+
+func TestCheckAll_maxFailures(t *testing.T) {
+	is := assert.New(t)
+
+	list := batchOrders(100, 3, 10, 11, 40, 90)
+	for _, workers := range []int{1, 4, 16} {
+		br := NewFactory().CheckAll(list, func(opt *BatchOption) {
+			opt.Workers = workers
+			opt.MaxFailures = 3
+		})
+
+		is.True(br.Stopped)
+		is.Eq([]int{3, 10, 11}, br.Failed)
+		is.Len(br.Errors, 3)
+		is.NotNil(br.Results[11])
+		for _, r := range br.Results[12:] {
+			is.Nil(r)
+		}
+	}
+
+	// concurrent, no limit: same as in order
+	br := CheckAll(list, func(opt *BatchOption) { opt.Workers = 8 })
+	is.False(br.Stopped)
+	is.Eq([]int{3, 10, 11, 40, 90}, br.Failed)
+	is.Eq("[3].Email", br.FieldErrors()[0].Field)
+	is.Eq("[90].Email", br.FieldErrors()[4].Field)
+
+	// exactly N failures, all validated
+	br = CheckAll(batchOrders(5, 4), func(opt *BatchOption) { opt.MaxFailures = 1 })
+	is.False(br.Stopped)
+	is.Eq([]int{4}, br.Failed)
+}
@@ -0,0 +1,331 @@
+package validate
+
+import "strings"
+
+// FieldBuilder is a fluent rule builder of the field(s), an alternative to the
+// rule strings. each method maps to a registered validator with the typed
+// arguments, and builds the same *Rule as the Validation.AddRule.
+//
+// The numeric compare arguments are float64, same as the JSON number. The
+// validators with any type arguments(eg: Eq, In) keep the any arguments.
+//
+// Usage:
+//
+//	v.AddFields(
+//		validate.Field("name").Required().IsString().MinLen(3).Trim(),
+//		validate.Field("age").Required().Int().Between(1, 99).Message("age must be 1-99"),
+//		validate.Field("email").Email().Scene("create"),
+//	)
+type FieldBuilder struct {
+	fields string
+	scene  string
+	rules  []*Rule
+	// the filter rule string. eg: "trim|lower"
+	filters []string
+	defVal  any
+	hasDef  bool
+}
+
+// Field create a rule builder of the field(s). multi fields split by ",".
+// eg: "name", "first_name,last_name", "tags.*"
+func Field(fields string) *FieldBuilder {
+	return &FieldBuilder{fields: fields}
+}
+
+// Rules build the rules of the field.
+func (fb *FieldBuilder) Rules() []*Rule { return fb.rules }
+
+// Rule add a validator with the args, for the custom validators and the
+// validators without a builder method.
+func (fb *FieldBuilder) Rule(validator string, args ...any) *FieldBuilder {
+	r := NewRule(fb.fields, validator, args...)
+	r.scene = fb.scene
+	fb.rules = append(fb.rules, r)
+	return fb
+}
+
+// Func add a custom validate func. see Rule.SetCheckFunc
+func (fb *FieldBuilder) Func(name string, checkFunc any, args ...any) *FieldBuilder {
+	fb.Rule(name, args...)
+	fb.lastRule("Func").SetCheckFunc(checkFunc)
+	return fb
+}
+
+// Message set the error message of the last rule.
+func (fb *FieldBuilder) Message(msg string) *FieldBuilder {
+	fb.lastRule("Message").SetMessage(msg)
+	return fb
+}
+
+// Scene set the scene name of all rules of the field.
+func (fb *FieldBuilder) Scene(scene string) *FieldBuilder {
+	fb.scene = scene
+	for _, r := range fb.rules {
+		r.scene = scene
+	}
+	return fb
+}
+
+// Filter add filters for the field. eg: "trim", "lower", "substr:0,3"
+//
+// The unknown filter is reported on AddFields, it panics or be collected on
+// CollectConfigErr is true.
+func (fb *FieldBuilder) Filter(filters ...string) *FieldBuilder {
+	fb.filters = append(fb.filters, filters...)
+	return fb
+}
+
+// Trim add the filter "trim" for the field
+func (fb *FieldBuilder) Trim() *FieldBuilder { return fb.Filter("trim") }
+
+// Lower add the filter "lower" for the field
+func (fb *FieldBuilder) Lower() *FieldBuilder { return fb.Filter("lower") }
+
+// Upper add the filter "upper" for the field
+func (fb *FieldBuilder) Upper() *FieldBuilder { return fb.Filter("upper") }
+
+// ToInt add the filter "int" for the field, convert the value to int
+func (fb *FieldBuilder) ToInt() *FieldBuilder { return fb.Filter("int") }
+
+// ToFloat add the filter "float" for the field, convert the value to float64
+func (fb *FieldBuilder) ToFloat() *FieldBuilder { return fb.Filter("float") }
+
+// ToBool add the filter "bool" for the field, convert the value to bool
+func (fb *FieldBuilder) ToBool() *FieldBuilder { return fb.Filter("bool") }
+
+// Default set the default value of the field.
+func (fb *FieldBuilder) Default(val any) *FieldBuilder {
+	fb.defVal, fb.hasDef = val, true
+	return fb
+}
+
+func (fb *FieldBuilder) lastRule(method string) *Rule {
+	if len(fb.rules) == 0 {
+		panicf("Field(%s): %s() must be called after a rule", fb.fields, method)
+	}
+	return fb.rules[len(fb.rules)-1]
+}
+
+// AddFields add the rules, filters and default values built by the FieldBuilder.
+//
+// The rules are copied to the Validation, so a FieldBuilder can be defined once
+// and reused.
+func (v *Validation) AddFields(fbs ...*FieldBuilder) *Validation {
+	for _, fb := range fbs {
+		for _, r := range fb.rules {
+			r = v.AppendRule(cloneRule(r))
+			if r.realName == RuleOptional {
+				r.optional = true
+				v.ensureOptionals() // lazy
+				for _, field := range r.fields {
+					v.optionals[field] = 0
+				}
+			}
+		}
+
+		if len(fb.filters) > 0 {
+			for _, name := range fb.filters {
+				if name, _, _ = strings.Cut(name, ":"); !v.hasFilter(name) {
+					v.configErrorf(fb.fields, name, "the filter '%s' does not exist", name)
+				}
+			}
+			v.FilterRule(fb.fields, strings.Join(fb.filters, "|"))
+		}
+		if fb.hasDef {
+			for _, field := range stringSplit(fb.fields, ",") {
+				v.SetDefValue(field, fb.defVal)
+			}
+		}
+	}
+	return v
+}
+
+/*************************************************************
+ * required and type validators
+ *************************************************************/
+
+// Required the field is required
+func (fb *FieldBuilder) Required() *FieldBuilder { return fb.Rule(RuleRequired) }
+
+// Optional only validate the field on the value is not empty
+func (fb *FieldBuilder) Optional() *FieldBuilder { return fb.Rule(RuleOptional) }
+
+// RequiredIf the field is required if the anotherField value is one of the values.
+func (fb *FieldBuilder) RequiredIf(anotherField string, values ...string) *FieldBuilder {
+	return fb.Rule("requiredIf", strings2Args(append([]string{anotherField}, values...))...)
+}
+
+// RequiredWith the field is required if any of the other fields are present.
+func (fb *FieldBuilder) RequiredWith(fields ...string) *FieldBuilder {
+	return fb.Rule("requiredWith", strings2Args(fields)...)
+}
+
+// Int value is an int
+func (fb *FieldBuilder) Int() *FieldBuilder { return fb.Rule("isInt") }
+
+// Uint value is an uint
+func (fb *FieldBuilder) Uint() *FieldBuilder { return fb.Rule("isUint") }
+
+// Float value is a float
+func (fb *FieldBuilder) Float() *FieldBuilder { return fb.Rule("isFloat") }
+
+// Bool value is a bool
+func (fb *FieldBuilder) Bool() *FieldBuilder { return fb.Rule("isBool") }
+
+// IsString value is a string
+func (fb *FieldBuilder) IsString() *FieldBuilder { return fb.Rule("isString") }
+
+// Strings value is a string slice
+func (fb *FieldBuilder) Strings() *FieldBuilder { return fb.Rule("isStrings") }
+
+// Ints value is an int slice
+func (fb *FieldBuilder) Ints() *FieldBuilder { return fb.Rule("isInts") }
+
+// Slice value is a slice
+func (fb *FieldBuilder) Slice() *FieldBuilder { return fb.Rule("isSlice") }
+
+// Map value is a map
+func (fb *FieldBuilder) Map() *FieldBuilder { return fb.Rule("isMap") }
+
+/*************************************************************
+ * value compare validators
+ *************************************************************/
+
+// Min value is greater than or equal to min. for int, uint and float
+func (fb *FieldBuilder) Min(min float64) *FieldBuilder { return fb.Rule("min", min) }
+
+// Max value is less than or equal to max. for int, uint and float
+func (fb *FieldBuilder) Max(max float64) *FieldBuilder { return fb.Rule("max", max) }
+
+// Gt value is greater than min
+func (fb *FieldBuilder) Gt(min float64) *FieldBuilder { return fb.Rule("gt", min) }
+
+// Lt value is less than max
+func (fb *FieldBuilder) Lt(max float64) *FieldBuilder { return fb.Rule("lt", max) }
+
+// Between value is between min and max(inclusive)
+func (fb *FieldBuilder) Between(min, max float64) *FieldBuilder {
+	return fb.Rule("between", min, max)
+}
+
+// Eq value is equal to the want
+func (fb *FieldBuilder) Eq(want any) *FieldBuilder { return fb.Rule("isEqual", want) }
+
+// Ne value is not equal to the want
+func (fb *FieldBuilder) Ne(want any) *FieldBuilder { return fb.Rule("notEqual", want) }
+
+// In value is one of the values
+func (fb *FieldBuilder) In(values ...any) *FieldBuilder { return fb.Rule("enum", values) }
+
+// NotIn value is not one of the values
+func (fb *FieldBuilder) NotIn(values ...any) *FieldBuilder { return fb.Rule("notIn", values) }
+
+/*************************************************************
+ * length validators
+ *************************************************************/
+
+// Len the length of the value is n. for string, slice, map
+func (fb *FieldBuilder) Len(n int) *FieldBuilder { return fb.Rule("length", n) }
+
+// MinLen the length of the value is at least min
+func (fb *FieldBuilder) MinLen(min int) *FieldBuilder { return fb.Rule("minLength", min) }
+
+// MaxLen the length of the value is at most max
+func (fb *FieldBuilder) MaxLen(max int) *FieldBuilder { return fb.Rule("maxLength", max) }
+
+// LenBetween the length of the value is between min and max(inclusive)
+func (fb *FieldBuilder) LenBetween(min, max int) *FieldBuilder {
+	return fb.Rule("stringLength", min, max)
+}
+
+/*************************************************************
+ * string validators
+ *************************************************************/
+
+// Email value is an email address
+func (fb *FieldBuilder) Email() *FieldBuilder { return fb.Rule("isEmail") }
+
+// URL value is a URL
+func (fb *FieldBuilder) URL() *FieldBuilder { return fb.Rule("isURL") }
+
+// FullURL value is a full URL, with the scheme
+func (fb *FieldBuilder) FullURL() *FieldBuilder { return fb.Rule("isFullURL") }
+
+// IP value is an IP(v4 or v6) address
+func (fb *FieldBuilder) IP() *FieldBuilder { return fb.Rule("isIP") }
+
+// IPv4 value is an IPv4 address
+func (fb *FieldBuilder) IPv4() *FieldBuilder { return fb.Rule("isIPv4") }
+
+// IPv6 value is an IPv6 address
+func (fb *FieldBuilder) IPv6() *FieldBuilder { return fb.Rule("isIPv6") }
+
+// Alpha value contains only letters
+func (fb *FieldBuilder) Alpha() *FieldBuilder { return fb.Rule("isAlpha") }
+
+// AlphaNum value contains only letters and numbers
+func (fb *FieldBuilder) AlphaNum() *FieldBuilder { return fb.Rule("isAlphaNum") }
+
+// AlphaDash value contains only letters, numbers, dashes and underscores
+func (fb *FieldBuilder) AlphaDash() *FieldBuilder { return fb.Rule("isAlphaDash") }
+
+// Number value is a number >= 0
+func (fb *FieldBuilder) Number() *FieldBuilder { return fb.Rule("isNumber") }
+
+// Numeric value is a numeric string
+func (fb *FieldBuilder) Numeric() *FieldBuilder { return fb.Rule("isNumeric") }
+
+// UUID value is a UUID string
+func (fb *FieldBuilder) UUID() *FieldBuilder { return fb.Rule("isUUID") }
+
+// JSON value is a JSON string
+func (fb *FieldBuilder) JSON() *FieldBuilder { return fb.Rule("isJSON") }
+
+// Regexp value matches the regexp pattern
+func (fb *FieldBuilder) Regexp(pattern string) *FieldBuilder { return fb.Rule(RuleRegexp, pattern) }
+
+// Contains value contains the sub. for string, slice, map
+func (fb *FieldBuilder) Contains(sub any) *FieldBuilder { return fb.Rule("contains", sub) }
+
+// StartsWith the string value starts with the prefix
+func (fb *FieldBuilder) StartsWith(prefix string) *FieldBuilder {
+	return fb.Rule("startsWith", prefix)
+}
+
+// EndsWith the string value ends with the suffix
+func (fb *FieldBuilder) EndsWith(suffix string) *FieldBuilder {
+	return fb.Rule("endsWith", suffix)
+}
+
+// Date value is a date string
+func (fb *FieldBuilder) Date() *FieldBuilder { return fb.Rule("isDate") }
+
+// AfterDate the date value is after the date. eg: "2006-01-02"
+func (fb *FieldBuilder) AfterDate(date string) *FieldBuilder { return fb.Rule("afterDate", date) }
+
+// BeforeDate the date value is before the date. eg: "2006-01-02"
+func (fb *FieldBuilder) BeforeDate(date string) *FieldBuilder {
+	return fb.Rule("beforeDate", date)
+}
+
+/*************************************************************
+ * field compare validators
+ *************************************************************/
+
+// EqField value is equal to the another field value
+func (fb *FieldBuilder) EqField(field string) *FieldBuilder { return fb.Rule("eqField", field) }
+
+// NeField value is not equal to the another field value
+func (fb *FieldBuilder) NeField(field string) *FieldBuilder { return fb.Rule("neField", field) }
+
+// GtField value is greater than the another field value
+func (fb *FieldBuilder) GtField(field string) *FieldBuilder { return fb.Rule("gtField", field) }
+
+// GteField value is greater than or equal to the another field value
+func (fb *FieldBuilder) GteField(field string) *FieldBuilder { return fb.Rule("gteField", field) }
+
+// LtField value is less than the another field value
+func (fb *FieldBuilder) LtField(field string) *FieldBuilder { return fb.Rule("ltField", field) }
+
+// LteField value is less than or equal to the another field value
+func (fb *FieldBuilder) LteField(field string) *FieldBuilder { return fb.Rule("lteField", field) }
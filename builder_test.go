@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestField_builder(t *testing.T) {
+	is := assert.New(t)
+
+	fb := Field("age").Required().Int().Between(1, 99).Message("age must be 1-99")
+	rs := fb.Rules()
+	is.Len(rs, 3)
+	is.Eq([]string{"age"}, rs[2].Fields())
+	is.Eq([]any{float64(1), float64(99)}, rs[2].arguments)
+	is.Eq("age must be 1-99", rs[2].message)
+
+	fields := []*FieldBuilder{
+		Field("name").Required().IsString().MinLen(3).Trim(),
+		fb,
+		Field("email").Optional().Email(),
+		Field("role").In("admin", "user").Default("user"),
+	}
+
+	v := Map(M{"name": " tom ", "age": 100})
+	v.AddFields(fields...)
+	is.False(v.Validate())
+	is.Eq("age must be 1-99", v.Errors.One())
+	is.Eq("tom", v.filteredData["name"])
+
+	// reuse the builders
+	v = Map(M{"name": "tom", "age": 20, "email": ""})
+	v.AddFields(fields...)
+	is.True(v.Validate())
+	is.Eq("user", v.safeData["role"])
+
+	v = Map(M{"name": "tom", "age": 20, "email": "bad", "role": "root"})
+	v.StopOnError = false
+	v.AddFields(fields...)
+	is.False(v.Validate())
+	is.True(v.Errors.HasField("email"))
+	is.True(v.Errors.HasField("role"))
+
+	// same as the rule string
+	built := Map(M{"age": 100}).AddFields(Field("age").Required().Int().Between(1, 99))
+	parsed := Map(M{"age": 100})
+	parsed.StringRule("age", "required|int|between:1,99")
+	is.Len(built.rules, len(parsed.rules))
+	for i, r := range parsed.rules {
+		is.Eq(r.fields, built.rules[i].fields)
+		is.Eq(r.realName, built.rules[i].realName)
+		is.Eq(fmt.Sprint(r.arguments), fmt.Sprint(built.rules[i].arguments))
+	}
+	is.False(built.Validate())
+	is.False(parsed.Validate())
+	is.Eq(parsed.Errors, built.Errors)
+}
+
+func TestField_builder_more(t *testing.T) {
+	is := assert.New(t)
+
+	// scene
+	v := Map(M{"name": ""})
+	v.AddFields(Field("name").Required().Scene("create"))
+	is.True(v.Validate("update"))
+
+	v = Map(M{"name": ""})
+	v.AddFields(Field("name").Required().Scene("create"))
+	is.False(v.Validate("create"))
+
+	// cross field and custom func
+	v = Map(M{"pwd": "abc123", "pwd2": "abc124", "code": "x1"})
+	v.StopOnError = false
+	v.AddFields(
+		Field("pwd2").Required().EqField("pwd").Message("passwords do not match"),
+		Field("code").Func("isX", func(val any) bool { return val == "x2" }).Message("code invalid"),
+		Field("code").Regexp(`^x\d$`).StartsWith("x").LenBetween(1, 3),
+	)
+	is.False(v.Validate())
+	is.Eq("passwords do not match", v.Errors.FieldOne("pwd2"))
+	is.Eq("code invalid", v.Errors.FieldOne("code"))
+
+	// struct data, with AppendRule
+	type user struct {
+		Name string
+		Age  int
+	}
+	v = Struct(&user{Name: "tom", Age: 200})
+	for _, r := range Field("Age").Max(150).Rules() {
+		v.AppendRule(r)
+	}
+	is.False(v.Validate())
+
+	is.PanicsMsg(func() {
+		Field("name").Message("msg")
+	}, "validate: Field(name): Message() must be called after a rule")
+
+	// the typed numeric args, the filter methods
+	v = Map(M{"price": "9.5", "code": " AB ", "on": "yes"})
+	v.StopOnError = false
+	v.AddFields(
+		Field("price").ToFloat().Between(0.5, 9.9).Gt(9.4).Lt(9.6),
+		Field("code").Trim().Lower().Filter("substr:0,1"),
+		Field("on").ToBool().Bool(),
+	)
+	is.True(v.Validate())
+	is.Eq(9.5, v.safeData["price"])
+	is.Eq("a", v.filteredData["code"])
+	is.Eq(true, v.safeData["on"])
+
+	// the unknown filter
+	v = Map(M{"name": "tom"})
+	v.CollectConfigErr = true
+	v.AddFields(Field("name").Required().Filter("trim", "trimX"))
+	is.ErrMsg(v.Err(), "field 'name': the filter 'trimX' does not exist")
+}
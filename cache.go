@@ -82,6 +82,15 @@ type typeMeta struct {
 	implConfig     bool // implements ConfigValidationFace
 	implTranslates bool // implements FieldTranslatorFace
 	implMessages   bool // implements CustomMessagesFace
+
+	// hasStructLevel reports whether the type or any nested struct type in its
+	// field graph implements StructLevelFace (value or pointer method set).
+	hasStructLevel bool
+
+	// genTrans is the translator for the generated validator, nil if the type
+	// cannot use it. built once by generatedTrans.
+	genOnce  sync.Once
+	genTrans *Translator
 }
 
 // typeKey is the cache key. tagVer is folded in so that a global tag-name
@@ -247,6 +256,7 @@ func buildTypeMeta(rt reflect.Type) *typeMeta {
 	tm.implConfig = rt.Implements(cvFaceType)
 	tm.implTranslates = rt.Implements(ftFaceType)
 	tm.implMessages = rt.Implements(cmFaceType)
+	tm.hasStructLevel = computeHasStructLevel(rt)
 
 	// classify static vs dynamic for rule-template caching (P3b). Computed via a
 	// dedicated type scan (see computeIsStatic) so the criteria are explicit and
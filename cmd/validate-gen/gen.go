@@ -0,0 +1,496 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/validate/v2"
+)
+
+type fieldKind uint8
+
+const (
+	kindUnknown fieldKind = iota
+	kindString
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindSlice
+	kindMap
+)
+
+// typeCheckers the type validators always pass on the field kind
+var typeCheckers = map[string]fieldKind{
+	"isString": kindString,
+	"isInt":    kindInt,
+	"isUint":   kindUint,
+	"isFloat":  kindFloat,
+	"isBool":   kindBool,
+	"isSlice":  kindSlice,
+	"isMap":    kindMap,
+}
+
+// compareOps the numeric compare validators
+var compareOps = map[string]string{
+	"min": ">=",
+	"max": "<=",
+	"gt":  ">",
+	"lt":  "<",
+}
+
+// stringFuncs the string validators without args -> func name in the validate package
+var stringFuncs = map[string]string{
+	"isEmail":     "IsEmail",
+	"isURL":       "IsURL",
+	"isFullURL":   "IsFullURL",
+	"isIP":        "IsIP",
+	"isIPv4":      "IsIPv4",
+	"isIPv6":      "IsIPv6",
+	"isCIDR":      "IsCIDR",
+	"isMAC":       "IsMAC",
+	"isAlpha":     "IsAlpha",
+	"isAlphaNum":  "IsAlphaNum",
+	"isAlphaDash": "IsAlphaDash",
+	"isUUID":      "IsUUID",
+	"isJSON":      "IsJSON",
+	"isASCII":     "IsASCII",
+	"isDNSName":   "IsDNSName",
+	"isHexColor":  "IsHexColor",
+	"isCnMobile":  "IsCnMobile",
+}
+
+type generator struct {
+	tag string
+	pkg string
+	// struct types and other type decls of the package
+	structs map[string]*ast.StructType
+	named   map[string]ast.Expr
+	// used imports of the generated code
+	imports map[string]bool
+	buf     bytes.Buffer
+}
+
+func newGenerator(tag string) *generator {
+	return &generator{
+		tag:     tag,
+		structs: make(map[string]*ast.StructType),
+		named:   make(map[string]ast.Expr),
+		imports: make(map[string]bool),
+	}
+}
+
+// generate the validators of the struct types in the package dir.
+func (g *generator) generate(dir string, typeNames []string) ([]byte, error) {
+	if err := g.parseDir(dir); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, name := range typeNames {
+		name = strings.TrimSpace(name)
+		st, ok := g.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %q not found in %s", name, dir)
+		}
+
+		g.buf.Reset()
+		if err := g.genStruct(name, st); err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by validate-gen. DO NOT EDIT.\n\n")
+	out.WriteString("package " + g.pkg + "\n\nimport (\n")
+	var std []string
+	for path := range g.imports {
+		std = append(std, path)
+	}
+	sort.Strings(std)
+	for _, path := range std {
+		out.WriteString(strconv.Quote(path) + "\n")
+	}
+	out.WriteString("\n\"github.com/gookit/validate/v2\"\n)\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format the generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) parseDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		af, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if g.pkg == "" {
+			g.pkg = af.Name.Name
+		} else if g.pkg != af.Name.Name {
+			return fmt.Errorf("multiple packages in %s: %s, %s", dir, g.pkg, af.Name.Name)
+		}
+
+		for _, decl := range af.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					g.structs[ts.Name.Name] = st
+				} else {
+					g.named[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+
+	if g.pkg == "" {
+		return fmt.Errorf("no go files in %s", dir)
+	}
+	return nil
+}
+
+func (g *generator) genStruct(name string, st *ast.StructType) error {
+	g.printf("\nvar _ validate.GeneratedValidator = (*%s)(nil)\n\n", name)
+	g.printf("// ValidateGenerated implements validate.GeneratedValidator\n")
+	g.printf("func (x *%s) ValidateGenerated(fail validate.GenFailFunc) {\n", name)
+
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return fmt.Errorf("embedded field %s is not supported", exprString(f.Type))
+		}
+
+		var tags reflect.StructTag
+		if f.Tag != nil {
+			s, _ := strconv.Unquote(f.Tag.Value)
+			tags = reflect.StructTag(s)
+		}
+
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			if err := g.genField(ident.Name, f.Type, tags); err != nil {
+				return fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+		}
+	}
+
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) genField(name string, typ ast.Expr, tags reflect.StructTag) error {
+	if g.isStructType(typ) {
+		return fmt.Errorf("nested struct field is not supported")
+	}
+
+	rule := strings.TrimSpace(tags.Get(g.tag))
+	if rule == "" || rule == "-" || rule == "safe" {
+		return nil
+	}
+	if tags.Get("filter") != "" {
+		return fmt.Errorf("filter tag is not supported")
+	}
+	if tags.Get("default") != "" {
+		return fmt.Errorf("default tag is not supported")
+	}
+
+	kind, basic := g.kindOf(typ, 0)
+	if kind == kindUnknown {
+		return fmt.Errorf("field type %s is not supported", exprString(typ))
+	}
+
+	fc := &fieldCode{name: name, kind: kind, val: "x." + name}
+	fc.str = fc.val
+	if kind == kindString && !basic {
+		fc.str = "string(" + fc.val + ")"
+	}
+
+	g.printf("// %s: %s\n", name, rule)
+	for _, item := range strings.Split(strings.Trim(rule, "|:"), "|") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		validator, argStr, _ := strings.Cut(item, ":")
+		if err := g.genRule(fc, strings.TrimSpace(validator), strings.TrimSpace(argStr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldCode the field info for generate rules
+type fieldCode struct {
+	name string
+	kind fieldKind
+	// the field value expr, str is the string value expr
+	val, str string
+}
+
+func (fc *fieldCode) emptyExpr() string {
+	switch fc.kind {
+	case kindString:
+		return fc.val + ` == ""`
+	case kindBool:
+		return "!" + fc.val
+	case kindSlice, kindMap:
+		return "len(" + fc.val + ") == 0"
+	}
+	return fc.val + " == 0"
+}
+
+func (fc *fieldCode) notEmptyExpr() string {
+	switch fc.kind {
+	case kindString:
+		return fc.val + ` != ""`
+	case kindBool:
+		return fc.val
+	case kindSlice, kindMap:
+		return "len(" + fc.val + ") != 0"
+	}
+	return fc.val + " != 0"
+}
+
+func (fc *fieldCode) isNumber() bool {
+	return fc.kind == kindInt || fc.kind == kindUint || fc.kind == kindFloat
+}
+
+func (g *generator) genRule(fc *fieldCode, validator, argStr string) error {
+	realName := validate.ValidatorName(validator)
+	args := splitArgs(argStr)
+
+	var (
+		ok      string
+		errArgs []string
+	)
+	switch {
+	case realName == validate.RuleRequired:
+		g.printf("if %s && fail(%q, %q, %s) {\nreturn\n}\n", fc.emptyExpr(), fc.name, validator, fc.val)
+		return nil
+	case typeCheckers[realName] != kindUnknown:
+		if typeCheckers[realName] != fc.kind {
+			return fmt.Errorf("validator %q is not supported on the field type", validator)
+		}
+		return nil // always pass
+	case compareOps[realName] != "" || realName == "between":
+		if !fc.isNumber() {
+			return fmt.Errorf("validator %q requires a number field", validator)
+		}
+
+		want := 1
+		if realName == "between" {
+			want = 2
+		}
+		if err := checkArgs(validator, args, want, fc.kind); err != nil {
+			return err
+		}
+
+		if realName == "between" {
+			ok = fmt.Sprintf("%s >= %s && %s <= %s", fc.val, args[0], fc.val, args[1])
+		} else {
+			ok = fmt.Sprintf("%s %s %s", fc.val, compareOps[realName], args[0])
+		}
+		errArgs = args
+	case realName == "minLength" || realName == "maxLength" || realName == "length":
+		if err := checkArgs(validator, args, 1, kindInt); err != nil {
+			return err
+		}
+
+		var lenExpr string
+		switch fc.kind {
+		case kindString:
+			g.imports["unicode/utf8"] = true
+			lenExpr = "utf8.RuneCountInString(" + fc.str + ")"
+		case kindSlice, kindMap:
+			lenExpr = "len(" + fc.val + ")"
+		default:
+			return fmt.Errorf("validator %q requires a string, slice or map field", validator)
+		}
+
+		op := map[string]string{"minLength": ">=", "maxLength": "<=", "length": "=="}[realName]
+		ok = fmt.Sprintf("%s %s %s", lenExpr, op, args[0])
+		errArgs = args
+	case realName == "enum" || realName == "notIn":
+		if fc.kind != kindString && fc.kind != kindInt && fc.kind != kindUint {
+			return fmt.Errorf("validator %q requires a string or int field", validator)
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("validator %q requires the args", validator)
+		}
+
+		op, join := "==", " || "
+		if realName == "notIn" {
+			op, join = "!=", " && "
+		}
+
+		conds := make([]string, len(args))
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = strconv.Quote(arg)
+			if fc.kind == kindString {
+				arg = quoted[i]
+			} else if err := checkArgs(validator, []string{arg}, 1, fc.kind); err != nil {
+				return err
+			}
+			conds[i] = fc.val + " " + op + " " + arg
+		}
+
+		ok = strings.Join(conds, join)
+		errArgs = []string{"[]string{" + strings.Join(quoted, ", ") + "}"}
+	case stringFuncs[realName] != "":
+		if fc.kind != kindString {
+			return fmt.Errorf("validator %q requires a string field", validator)
+		}
+		ok = "validate." + stringFuncs[realName] + "(" + fc.str + ")"
+	case realName == "startsWith" || realName == "endsWith":
+		if fc.kind != kindString || len(args) != 1 {
+			return fmt.Errorf("validator %q requires a string field and one arg", validator)
+		}
+
+		g.imports["strings"] = true
+		fn := map[string]string{"startsWith": "HasPrefix", "endsWith": "HasSuffix"}[realName]
+		ok = fmt.Sprintf("strings.%s(%s, %q)", fn, fc.str, args[0])
+		errArgs = []string{strconv.Quote(args[0])}
+	default:
+		return fmt.Errorf("validator %q is not supported", validator)
+	}
+
+	if strings.ContainsRune(ok, ' ') {
+		ok = "(" + ok + ")"
+	}
+
+	failArgs := ""
+	if len(errArgs) > 0 {
+		failArgs = ", " + strings.Join(errArgs, ", ")
+	}
+	g.printf("if %s && !%s && fail(%q, %q, %s%s) {\nreturn\n}\n", fc.notEmptyExpr(), ok, fc.name, validator, fc.val, failArgs)
+	return nil
+}
+
+// kindOf get the field kind of the type expr. basic is false for a named type.
+func (g *generator) kindOf(typ ast.Expr, depth int) (kind fieldKind, basic bool) {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return kindString, true
+		case "int", "int8", "int16", "int32", "int64", "rune":
+			return kindInt, true
+		case "uint", "uint8", "uint16", "uint32", "uint64", "byte", "uintptr":
+			return kindUint, true
+		case "float32", "float64":
+			return kindFloat, true
+		case "bool":
+			return kindBool, true
+		}
+
+		if ut, ok := g.named[t.Name]; ok && depth < 10 {
+			kind, _ = g.kindOf(ut, depth+1)
+			return kind, false
+		}
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return kindSlice, true
+		}
+	case *ast.MapType:
+		return kindMap, true
+	}
+	return kindUnknown, false
+}
+
+// isStructType check the type is a struct type of the package, or the pointer,
+// slice, map of it. the runtime validates the fields of them.
+func (g *generator) isStructType(typ ast.Expr) bool {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		_, ok := g.structs[t.Name]
+		return ok
+	case *ast.StructType:
+		return true
+	case *ast.StarExpr:
+		return g.isStructType(t.X)
+	case *ast.ArrayType:
+		return g.isStructType(t.Elt)
+	case *ast.MapType:
+		return g.isStructType(t.Value)
+	}
+	return false
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// splitArgs split the rule args, same as the runtime.
+func splitArgs(argStr string) []string {
+	if argStr == "" {
+		return nil
+	}
+	if len(argStr) == 1 {
+		return []string{argStr}
+	}
+
+	var args []string
+	for _, arg := range strings.Split(argStr, ",") {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// checkArgs check the number and the literal of the args.
+func checkArgs(validator string, args []string, want int, kind fieldKind) error {
+	if len(args) != want {
+		return fmt.Errorf("validator %q requires %d args", validator, want)
+	}
+
+	for _, arg := range args {
+		var err error
+		switch kind {
+		case kindInt:
+			_, err = strconv.ParseInt(arg, 10, 64)
+		case kindUint:
+			_, err = strconv.ParseUint(arg, 10, 64)
+		case kindFloat:
+			_, err = strconv.ParseFloat(arg, 64)
+		}
+		if err != nil {
+			return fmt.Errorf("validator %q: invalid arg %q", validator, arg)
+		}
+	}
+	return nil
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+// the committed example code must be same as the generated.
+func TestGenerate_example(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	src, err := newGenerator("validate").generate(dir, []string{"User", "Order"})
+	assert.NoErr(t, err)
+
+	want, err := os.ReadFile(filepath.Join(dir, "types_validate.go"))
+	assert.NoErr(t, err)
+	assert.Eq(t, string(want), string(src), "run go generate ./cmd/validate-gen/...")
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := []struct {
+		code, err string
+	}{
+		{"type T struct{ Name string `validate:\"regexp:^a\"` }", `validator "regexp" is not supported`},
+		{"type T struct{ Name string `validate:\"min:1\"` }", `requires a number field`},
+		{"type T struct{ Age int `validate:\"min:a\"` }", `invalid arg "a"`},
+		{"type T struct{ Age int `validate:\"between:1\"` }", `requires 2 args`},
+		{"type T struct{ Name string `validate:\"required\" filter:\"trim\"` }", `filter tag is not supported`},
+		{"type T struct{ Age int `validate:\"expr:Age > 1\"` }", `validator "expr" is not supported`},
+		{"type T struct{ Name *string `validate:\"required\"` }", `field type *string is not supported`},
+		{"type T struct{ Sub S }\ntype S struct{ Name string }", `nested struct field is not supported`},
+		{"type T struct{ S }\ntype S struct{ Name string }", `embedded field S is not supported`},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		code := "package p\n\n" + tt.code + "\n"
+		assert.NoErr(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte(code), 0o644))
+
+		_, err := newGenerator("validate").generate(dir, []string{"T"})
+		assert.ErrSubMsg(t, err, tt.err)
+	}
+
+	_, err := newGenerator("validate").generate(t.TempDir(), []string{"T"})
+	assert.ErrSubMsg(t, err, "no go files")
+}
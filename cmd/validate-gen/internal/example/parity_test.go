@@ -0,0 +1,96 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+	"github.com/gookit/validate/v2"
+)
+
+var users = []User{
+	{},
+	{Name: "tom", Email: "tom@example.com", Age: 20, Role: "admin", Site: "https://a.com", Tags: []string{"a"}, Score: 50},
+	{Name: "to", Email: "tom@example.com"},
+	{Name: "一二三", Email: "invalid"},
+	{Name: "tom", Email: "tom@example.com", Age: 121, Role: "root"},
+	{Name: "tom", Email: "tom@example.com", Age: -1, Site: "http://a.com"},
+	{Name: "tom", Email: "tom@example.com", Site: "not a url", Tags: []string{"a", "b", "c", "d"}},
+	{Name: "tom", Email: "tom@example.com", Score: 0.1},
+	{Name: "tom", Email: "tom@example.com", Score: 100},
+	{Name: "abcdefghijklmnopqrstuvwxyz", Email: "x@y", Role: "guest"},
+}
+
+var orders = []Order{
+	{},
+	{ID: 1, Status: "paid", Qty: 2, Paid: true, Meta: map[string]string{"a": "1", "b": "2"}},
+	{ID: 1, Status: "closed", Paid: true},
+	{ID: 1, Status: "a b", Paid: true},
+	{ID: 1, Status: "new", Qty: -1, Paid: true},
+	{ID: 1, Status: "new", Qty: 100, Paid: true, Meta: map[string]string{"a": "1"}},
+	{ID: 2, Status: "new"},
+}
+
+// compare the generated and reflection (Struct) results.
+func assertParity(t *testing.T, ptr validate.GeneratedValidator) {
+	ref := validate.Struct(ptr).ValidateR()
+
+	// the failed rules of the generated code
+	type failed struct{ field, validator string }
+	var gen []failed
+	ptr.ValidateGenerated(func(field, validator string, _ any, _ ...any) bool {
+		gen = append(gen, failed{field, validator})
+		return validate.Option().StopOnError
+	})
+
+	is := assert.New(t)
+	is.Eq(len(ref.FieldErrors()), len(gen))
+	for i, fe := range ref.FieldErrors() {
+		is.Eq(failed{fe.Field, fe.Alias}, gen[i])
+	}
+
+	genErr := validate.CheckErr(ptr)
+	if ref.IsOK() {
+		is.Nil(genErr)
+	} else {
+		is.Err(genErr)
+		is.Eq(ref.FieldErrors()[0].Message, genErr.Error())
+	}
+
+	// Check: the same errors and safe data
+	r := validate.Check(ptr)
+	is.Eq(ref.Errors, r.Errors)
+	is.Eq(ref.SafeData(), r.SafeData())
+	is.Eq(ref.One(), r.One())
+	is.Len(r.FieldErrors(), len(ref.FieldErrors()))
+	for i, fe := range r.FieldErrors() {
+		is.Eq(ref.FieldErrors()[i].Path, fe.Path)
+	}
+}
+
+func TestParity(t *testing.T) {
+	for _, stop := range []bool{true, false} {
+		validate.Config(func(opt *validate.GlobalOption) {
+			opt.StopOnError = stop
+		})
+
+		for i := range users {
+			assertParity(t, &users[i])
+		}
+		for i := range orders {
+			assertParity(t, &orders[i])
+		}
+	}
+	validate.ResetOption()
+}
+
+func TestParity_message(t *testing.T) {
+	u := &User{Name: "tom", Email: "tom@example.com", Score: 0.1}
+	err := validate.CheckErr(u)
+	assert.Err(t, err)
+	assert.Eq(t, "score is too low", err.Error())
+
+	u = &User{Name: "to", Email: "tom@example.com"}
+	err = validate.CheckErr(u)
+	assert.Err(t, err)
+	assert.StrContains(t, err.Error(), "User Name")
+}
@@ -0,0 +1,30 @@
+// Package example is the example types for validate-gen, and the parity tests
+// of the generated and reflection validators.
+package example
+
+//go:generate go run ../.. -type User,Order
+
+// Status of the order
+type Status string
+
+// User example
+type User struct {
+	Name  string   `json:"name" validate:"required|minLen:3|maxLen:20" label:"User Name"`
+	Email string   `json:"email" validate:"required|email"`
+	Age   int      `json:"age" validate:"int|between:1,120"`
+	Role  string   `json:"role" validate:"in:admin,user,guest"`
+	Site  string   `validate:"url|startsWith:https"`
+	Tags  []string `validate:"maxLen:3"`
+	Score float64  `validate:"min:0.5|max:99.5" message:"min:score is too low"`
+
+	note string
+}
+
+// Order example
+type Order struct {
+	ID     uint              `validate:"required|gt:0"`
+	Status Status            `validate:"required|notIn:closed,deleted|alphaDash"`
+	Qty    int32             `validate:"min:1|lt:100"`
+	Paid   bool              `validate:"required"`
+	Meta   map[string]string `validate:"len:2"`
+}
@@ -0,0 +1,97 @@
+// Code generated by validate-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gookit/validate/v2"
+)
+
+var _ validate.GeneratedValidator = (*User)(nil)
+
+// ValidateGenerated implements validate.GeneratedValidator
+func (x *User) ValidateGenerated(fail validate.GenFailFunc) {
+	// Name: required|minLen:3|maxLen:20
+	if x.Name == "" && fail("Name", "required", x.Name) {
+		return
+	}
+	if x.Name != "" && !(utf8.RuneCountInString(x.Name) >= 3) && fail("Name", "minLen", x.Name, 3) {
+		return
+	}
+	if x.Name != "" && !(utf8.RuneCountInString(x.Name) <= 20) && fail("Name", "maxLen", x.Name, 20) {
+		return
+	}
+	// Email: required|email
+	if x.Email == "" && fail("Email", "required", x.Email) {
+		return
+	}
+	if x.Email != "" && !validate.IsEmail(x.Email) && fail("Email", "email", x.Email) {
+		return
+	}
+	// Age: int|between:1,120
+	if x.Age != 0 && !(x.Age >= 1 && x.Age <= 120) && fail("Age", "between", x.Age, 1, 120) {
+		return
+	}
+	// Role: in:admin,user,guest
+	if x.Role != "" && !(x.Role == "admin" || x.Role == "user" || x.Role == "guest") && fail("Role", "in", x.Role, []string{"admin", "user", "guest"}) {
+		return
+	}
+	// Site: url|startsWith:https
+	if x.Site != "" && !validate.IsURL(x.Site) && fail("Site", "url", x.Site) {
+		return
+	}
+	if x.Site != "" && !(strings.HasPrefix(x.Site, "https")) && fail("Site", "startsWith", x.Site, "https") {
+		return
+	}
+	// Tags: maxLen:3
+	if len(x.Tags) != 0 && !(len(x.Tags) <= 3) && fail("Tags", "maxLen", x.Tags, 3) {
+		return
+	}
+	// Score: min:0.5|max:99.5
+	if x.Score != 0 && !(x.Score >= 0.5) && fail("Score", "min", x.Score, 0.5) {
+		return
+	}
+	if x.Score != 0 && !(x.Score <= 99.5) && fail("Score", "max", x.Score, 99.5) {
+		return
+	}
+}
+
+var _ validate.GeneratedValidator = (*Order)(nil)
+
+// ValidateGenerated implements validate.GeneratedValidator
+func (x *Order) ValidateGenerated(fail validate.GenFailFunc) {
+	// ID: required|gt:0
+	if x.ID == 0 && fail("ID", "required", x.ID) {
+		return
+	}
+	if x.ID != 0 && !(x.ID > 0) && fail("ID", "gt", x.ID, 0) {
+		return
+	}
+	// Status: required|notIn:closed,deleted|alphaDash
+	if x.Status == "" && fail("Status", "required", x.Status) {
+		return
+	}
+	if x.Status != "" && !(x.Status != "closed" && x.Status != "deleted") && fail("Status", "notIn", x.Status, []string{"closed", "deleted"}) {
+		return
+	}
+	if x.Status != "" && !validate.IsAlphaDash(string(x.Status)) && fail("Status", "alphaDash", x.Status) {
+		return
+	}
+	// Qty: min:1|lt:100
+	if x.Qty != 0 && !(x.Qty >= 1) && fail("Qty", "min", x.Qty, 1) {
+		return
+	}
+	if x.Qty != 0 && !(x.Qty < 100) && fail("Qty", "lt", x.Qty, 100) {
+		return
+	}
+	// Paid: required
+	if !x.Paid && fail("Paid", "required", x.Paid) {
+		return
+	}
+	// Meta: len:2
+	if len(x.Meta) != 0 && !(len(x.Meta) == 2) && fail("Meta", "len", x.Meta, 2) {
+		return
+	}
+}
@@ -0,0 +1,65 @@
+// Command validate-gen generates reflection-free validators from the struct
+// tags. The generated code implements validate.GeneratedValidator, which is
+// preferred by validate.Check and validate.CheckErr.
+//
+// Usage:
+//
+//	//go:generate go run github.com/gookit/validate/v2/cmd/validate-gen -type User,Order
+//
+// Flags:
+//
+//	-type    comma-separated struct type names, required
+//	-output  output file name. default is "<GOFILE>_validate.go" or "validate_gen.go"
+//	-tag     the validate tag name. default is "validate"
+//
+// Only the flat structs are supported, the fields of a nested struct, filter
+// and default tags, or unsupported validators are reported as an error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated struct type names, required")
+	output := flag.String("output", "", "output file name")
+	tagName := flag.String("tag", "validate", "the validate tag name")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	g := newGenerator(*tagName)
+	src, err := g.generate(dir, strings.Split(*typeNames, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validate-gen:", err)
+		os.Exit(1)
+	}
+
+	name := *output
+	if name == "" {
+		name = "validate_gen.go"
+		if gofile := os.Getenv("GOFILE"); gofile != "" {
+			name = strings.TrimSuffix(gofile, ".go") + "_validate.go"
+		}
+	}
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+
+	if err = os.WriteFile(name, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "validate-gen:", err)
+		os.Exit(1)
+	}
+}
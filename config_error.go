@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConfigError is a bad rule config error. eg: unknown validator, the rule
+// args number mismatch, invalid dive rules or rule expression.
+//
+// It is a programming or config error, not a user input error, it wraps ErrConfig.
+type ConfigError struct {
+	// File the rule file name, only set by the func LoadRuleFile. can be empty.
+	File string
+	// Line the line number in the rule file, 0 if unknown.
+	Line int
+	// Type the struct type name, only set by the func Precompile.
+	Type string
+	// Field the rule field name, can be empty.
+	Field string
+	// Validator the validator or filter name in the rule, can be empty.
+	Validator string
+	// Msg the error message
+	Msg string
+}
+
+// Error string
+func (e *ConfigError) Error() string {
+	var sb strings.Builder
+	if e.Line > 0 {
+		if e.File != "" {
+			sb.WriteString(e.File)
+			sb.WriteByte(':')
+		} else {
+			sb.WriteString("line ")
+		}
+		sb.WriteString(strconv.Itoa(e.Line))
+		sb.WriteString(": ")
+	}
+	if e.Type != "" {
+		sb.WriteString(e.Type)
+		sb.WriteString(": ")
+	}
+	if e.Field != "" {
+		sb.WriteString("field '")
+		sb.WriteString(e.Field)
+		sb.WriteString("': ")
+	}
+	sb.WriteString(e.Msg)
+	return sb.String()
+}
+
+// Unwrap returns ErrConfig
+func (e *ConfigError) Unwrap() error { return ErrConfig }
+
+// ConfigErrors the bad rule config errors list.
+type ConfigErrors []*ConfigError
+
+// Error string, one error per line.
+func (es ConfigErrors) Error() string {
+	var sb strings.Builder
+	for i, e := range es {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(e.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns all errors, errors.Is(err, ErrConfig) is true.
+func (es ConfigErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// configErrorf on CollectConfigErr is true, collect the bad rule config error.
+// otherwise panic with the message.
+func (v *Validation) configErrorf(field, validator, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if !v.CollectConfigErr {
+		panic("validate: " + msg)
+	}
+
+	// same error of a rule may be reported multi times. eg: dive elements
+	for _, e := range v.configErrs {
+		if e.Field == field && e.Validator == validator && e.Msg == msg {
+			return
+		}
+	}
+	v.configErrs = append(v.configErrs, &ConfigError{Field: field, Validator: validator, Msg: msg})
+}
+
+// addConfigErrors report the collected config errors under the "_config" key.
+func (v *Validation) addConfigErrors() {
+	for _, e := range v.configErrs {
+		v.AddError(configError, e.Validator, e.Error())
+	}
+}
+
+// Err returns the collected bad rule config errors, nil if no error.
+// The error is ConfigErrors, and errors.Is(err, ErrConfig) is true.
+//
+// see Validation.CollectConfigErr, Validation.Precompile
+func (v *Validation) Err() error {
+	if len(v.configErrs) == 0 {
+		return nil
+	}
+	return v.configErrs
+}
@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestValidation_CollectConfigErr(t *testing.T) {
+	is := assert.New(t)
+
+	// default: panic on bad rules
+	is.Panics(func() {
+		Map(M{"tags": []string{"a"}}).StringRule("tags", "dive|keys|alpha")
+	})
+	is.PanicsMsg(func() {
+		v := Map(M{"name": "inhere"})
+		v.StringRule("name", "required|notExists")
+		v.Validate()
+	}, "validate: the validator 'notExists' does not exist")
+
+	v := Map(M{"name": "inhere", "tags": []string{"a"}})
+	v.CollectConfigErr = true
+	v.StringRule("tags", "dive|keys|alpha")
+	v.StringRule("name", "required|(email or )")
+	v.FilterRule("name", "|")
+	v.AddValidator("bad", "not func")
+
+	err := v.Err()
+	is.ErrIs(err, ErrConfig)
+	ces := err.(ConfigErrors)
+	is.Len(ces, 4)
+	is.Eq("tags", ces[0].Field)
+	is.Eq(RuleDive, ces[0].Validator)
+	is.StrContains(ces[0].Error(), "field 'tags': invalid dive rules")
+	is.Eq(RuleExprName, ces[1].Validator)
+	is.StrContains(ces[2].Msg, "add filter rule")
+	is.Eq("bad", ces[3].Validator)
+	is.Eq("", ces[3].Field)
+
+	// the rules are not run
+	err = v.ValidateErr()
+	is.ErrIs(err, ErrConfig)
+	is.Eq(ces.Error(), err.Error())
+	is.True(v.Errors.HasField(configError))
+	is.Len(v.FieldErrors(), 4)
+}
+
+func TestValidation_CollectConfigErr_runtime(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere", "age": 10, "tags": []string{"a", "b"}})
+	v.CollectConfigErr = true
+	v.StopOnError = false
+	v.StringRule("name", "required|notExists")
+	v.StringRule("age", "min:1,2")
+	v.AddRule("tags", "dive", "in:a,b|")
+	v.StringRule("tags", "dive|keys|alpha|endkeys|required")
+	is.NoErr(v.Err())
+
+	is.False(v.Validate())
+	err := v.Err()
+	is.ErrIs(err, ErrConfig)
+	ces := err.(ConfigErrors)
+	is.Len(ces, 3)
+	is.Eq("the validator 'notExists' does not exist", ces[0].Msg)
+	is.StrContains(ces[1].Msg, "validator 'min', want 2, given 3")
+	// reported once for the all elements
+	is.StrContains(ces[2].Msg, "can only be used to dive into a map")
+
+	// ValidResult
+	v = Map(M{"name": "inhere"})
+	v.CollectConfigErr = true
+	v.StringRule("name", "required|notExists")
+	r := v.ValidateR()
+	is.True(r.Fail())
+	is.ErrIs(r.Err(), ErrConfig)
+
+	// the empty field rules are checked too
+	v = Map(M{})
+	v.CollectConfigErr = true
+	v.StringRule("name", "requird|minLen:2")
+	v.FilterRule("name", "trimx")
+	err = v.ValidateErr()
+	is.ErrIs(err, ErrConfig)
+	ces = err.(ConfigErrors)
+	is.Len(ces, 2)
+	is.Eq("requird", ces[0].Validator)
+	is.Eq("trimx", ces[1].Validator)
+	is.ErrIs(v.Err(), ErrConfig)
+}
+
+func TestValidation_Precompile(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"name": "inhere", "age": 10})
+	v.StringRule("name", "required|minLen:2|notExists")
+	v.StringRule("age", "int|between:1")
+	v.AddRule("age", "eqField", "name")
+	v.StringRule("tags", "dive|in:a,b")
+
+	err := v.Precompile()
+	is.ErrIs(err, ErrConfig)
+	is.False(v.CollectConfigErr)
+	ces := err.(ConfigErrors)
+	is.Len(ces, 2)
+	is.Eq("name", ces[0].Field)
+	is.Eq("notExists", ces[0].Validator)
+	is.Eq("between", ces[1].Validator)
+
+	// call again, no duplicate errors
+	is.Len(v.Precompile(), 2)
+	is.False(v.Validate())
+	is.ErrIs(v.ValidateErr(), ErrConfig)
+
+	v = Map(M{"name": "inhere"})
+	v.StringRule("name", "required|minLen:2")
+	is.NoErr(v.Precompile())
+	is.True(v.Validate())
+
+	// Reset clear the config errors
+	v = Map(M{"name": "inhere"})
+	v.StringRule("name", "notExists")
+	is.Err(v.Precompile())
+	v.Reset()
+	is.NoErr(v.Err())
+}
+
+type cfgErrUser struct {
+	Name  string `json:"name" validate:"required|notExists"`
+	Email string `json:"email" validate:"(email or )"`
+}
+
+func TestStruct_CollectConfigErr(t *testing.T) {
+	is := assert.New(t)
+
+	is.Panics(func() {
+		_ = Struct(&cfgErrUser{})
+	})
+
+	Config(func(opt *GlobalOption) {
+		opt.CollectConfigErr = true
+	})
+	defer ResetOption()
+
+	u := &cfgErrUser{Name: "inhere", Email: "a@b.com"}
+	r := Check(u)
+	is.True(r.Fail())
+	is.ErrIs(r.Err(), ErrConfig)
+
+	err := CheckErr(u)
+	is.ErrIs(err, ErrConfig)
+	is.StrContains(err.Error(), "field 'Email'")
+
+	v := Struct(u)
+	ces := v.Err().(ConfigErrors)
+	is.Len(ces, 1)
+	is.Len(v.Precompile(), 2)
+	is.True(errors.Is(v.ValidateErr(), ErrConfig))
+
+	// the empty field
+	type emptyUser struct {
+		Name string `validate:"requird" filter:"trimx"`
+	}
+	err = Struct(&emptyUser{}).ValidateErr()
+	is.ErrIs(err, ErrConfig)
+	is.Len(err.(ConfigErrors), 2)
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+)
+
+// ValidateCtx do validate processing with the context.
+//
+// The ctx can be read in the validators by FieldCtx.Context(), or use the
+// validator style: func(ctx context.Context, fc FieldCtx) bool
+//
+// The validation stops promptly when the ctx is canceled or deadline exceeded,
+// the stop is reported as an error under the "_context" key, and
+// Validation.CtxErr() returns an error wraps ErrCanceled and the ctx.Err().
+func (v *Validation) ValidateCtx(ctx context.Context, scene ...string) bool {
+	v.ctx = ctx
+	return v.Validate(scene...)
+}
+
+// Context get the context of the validating. default is context.Background()
+func (v *Validation) Context() context.Context {
+	if v.ctx == nil {
+		return context.Background()
+	}
+	return v.ctx
+}
+
+// CtxErr returns the error if the validation is stopped by the context,
+// it wraps ErrCanceled and the ctx.Err(). otherwise returns nil.
+func (v *Validation) CtxErr() error { return v.ctxErr }
+
+// ctxDone check the context is done. on done, add the context error and stop.
+func (v *Validation) ctxDone() bool {
+	if v.ctx == nil {
+		return false
+	}
+	if v.ctxErr != nil {
+		return true
+	}
+
+	err := v.ctx.Err()
+	if err == nil {
+		return false
+	}
+
+	v.ctxErr = fmt.Errorf("%w: %w", ErrCanceled, err)
+	v.AddError(contextError, contextError, v.ctxErr.Error())
+	return true
+}
+
+// CheckCtx is like Check, with the context. see Validation.ValidateCtx
+//
+// Usage:
+//
+//	r := validate.CheckCtx(req.Context(), &user)
+//	if errors.Is(r.Err(), validate.ErrCanceled) {
+//		// canceled or deadline exceeded
+//	}
+func CheckCtx(ctx context.Context, structPtr any, scene ...string) *ValidResult {
+	v := defaultFactory.Struct(structPtr, scene...)
+	v.ctx = ctx
+	return v.ValidateR()
+}
+
+// CheckErrCtx is like CheckErr, with the context. see Validation.ValidateCtx
+func CheckErrCtx(ctx context.Context, structPtr any, scene ...string) error {
+	v := defaultFactory.Struct(structPtr, scene...)
+	v.ctx = ctx
+	v.skipCollect = true // must precede Validate so applyField skips collection
+	v.Validate()
+	err := v.firstError()
+	v.Release()
+	return err
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type ctxKey string
+
+type ctxUser struct {
+	Name   string `validate:"required|tenantName"`
+	Email  string `validate:"required|email"`
+	Tenant string
+}
+
+func TestValidation_ValidateCtx(t *testing.T) {
+	is := assert.New(t)
+
+	ctx := context.WithValue(context.Background(), ctxKey("tenant"), "acme")
+	tenantName := func(ctx context.Context, fc FieldCtx) bool {
+		tenant, _ := ctx.Value(ctxKey("tenant")).(string)
+		return tenant != "" && fc.Value().String() != tenant
+	}
+
+	v := Struct(&ctxUser{Name: "tom", Email: "tom@example.com"})
+	v.AddValidator("tenantName", tenantName)
+	is.True(v.ValidateCtx(ctx))
+	is.Eq(ctx, v.Context())
+	is.NoErr(v.CtxErr())
+
+	v = Struct(&ctxUser{Name: "acme", Email: "tom@example.com"})
+	v.AddValidator("tenantName", tenantName)
+	is.False(v.ValidateCtx(ctx))
+	is.True(v.Errors.HasField("Name"))
+
+	// without ctx: context.Background()
+	v = Struct(&ctxUser{Name: "acme", Email: "tom@example.com"})
+	v.AddValidator("tenantName", tenantName)
+	is.False(v.Validate())
+
+	// FieldCtx.Context()
+	v = Map(map[string]any{"name": "acme"})
+	v.AddValidator("fcTenant", func(fc FieldCtx) bool {
+		return fc.Context().Value(ctxKey("tenant")) == "acme"
+	})
+	v.StringRule("name", "fcTenant")
+	is.True(v.ValidateCtx(ctx))
+}
+
+func TestValidation_ValidateCtx_canceled(t *testing.T) {
+	is := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	v := Map(map[string]any{"a": "1", "b": "2", "c": "3"})
+	v.StopOnError = false
+	v.AddValidator("slow", func(ctx context.Context, fc FieldCtx) bool {
+		calls++
+		cancel() // canceled on the first field
+		return true
+	})
+	v.StringRule("a", "slow")
+	v.StringRule("b", "slow")
+	v.StringRule("c", "slow")
+
+	is.False(v.ValidateCtx(ctx))
+	is.Eq(1, calls)
+	is.True(errors.Is(v.CtxErr(), ErrCanceled))
+	is.True(errors.Is(v.CtxErr(), context.Canceled))
+	is.Len(v.Errors, 1)
+	is.True(v.Errors.HasField(contextError))
+	is.Eq("validation canceled: context canceled", v.Errors.One())
+
+	// done before validate
+	r := CheckCtx(ctx, &ctxUser{Name: "tom", Email: "bad"})
+	is.True(r.Fail())
+	is.True(errors.Is(r.Err(), ErrCanceled))
+	is.Eq("", r.Problem().Errors[0].Pointer)
+
+	err := CheckErrCtx(ctx, &ctxUser{Name: "tom", Email: "bad"})
+	is.True(errors.Is(err, context.Canceled))
+
+	// not canceled
+	r = CheckCtx(context.Background(), &checkUser{Name: "ab", Email: "bad", Age: 20})
+	is.True(r.Fail())
+	is.False(errors.Is(r.Err(), ErrCanceled))
+	is.NoErr(CheckErrCtx(context.Background(), &checkUser{Name: "tom", Email: "tom@example.com", Age: 20}))
+}
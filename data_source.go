@@ -218,6 +218,7 @@ var (
 	cmFaceType = reflect.TypeOf(new(CustomMessagesFace)).Elem()
 	ftFaceType = reflect.TypeOf(new(FieldTranslatorFace)).Elem()
 	cvFaceType = reflect.TypeOf(new(ConfigValidationFace)).Elem()
+	slFaceType = reflect.TypeOf(new(StructLevelFace)).Elem()
 	timeType   = reflect.TypeOf(time.Time{})
 )
 
@@ -257,8 +258,6 @@ func (d *StructData) Create(err ...error) *Validation {
 // instance (which must already have v.data == d). Split out from Create so the
 // opt-in Factory can assemble onto a pooled instance, while the default path
 // (Create -> NewValidation) stays byte-for-byte identical.
-//
-//nolint:forcetypeassert
 func (d *StructData) createInto(v *Validation, err ...error) *Validation {
 	if len(err) > 0 && err[0] != nil {
 		return v.WithError(err[0])
@@ -274,7 +273,18 @@ func (d *StructData) createInto(v *Validation, err ...error) *Validation {
 	} else {
 		d.parseRulesFromTag(v)
 	}
+	d.configInto(v)
+
+	// for struct, default update source value
+	v.UpdateSource = true
+	return v
+}
 
+// configInto call the struct methods to config the validation: ConfigValidation,
+// Translates and Messages.
+//
+//nolint:forcetypeassert
+func (d *StructData) configInto(v *Validation) {
 	// reuse one-shot Implements results from cached type meta (avoids three
 	// per-instance reflect Implements calls). Fall back to live checks if meta
 	// is absent (e.g. a manually-built StructData).
@@ -299,10 +309,6 @@ func (d *StructData) createInto(v *Validation, err ...error) *Validation {
 		vs := fv.Call(nil)
 		v.WithMessages(vs[0].Interface().(map[string]string))
 	}
-
-	// for struct, default update source value
-	v.UpdateSource = true
-	return v
 }
 
 // implFaces returns the one-shot optional-interface implements results. Uses
@@ -0,0 +1,389 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Descriptor is the read-only description of the rules of a Validation, a
+// struct type or a RuleSet. It can be used to render docs, export the rules
+// to the frontend or write the rule coverage tests.
+//
+// It is a snapshot, changing it does not affect the rules.
+type Descriptor struct {
+	// Fields the described fields, in the order of the rules added.
+	Fields []*FieldDesc
+	// Scenes the scenes config. {scene: [field, ...]}
+	Scenes SValues
+}
+
+// Field get the field description by field name, nil if not found.
+func (d *Descriptor) Field(name string) *FieldDesc {
+	for _, f := range d.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// FieldNames returns the described field names
+func (d *Descriptor) FieldNames() []string {
+	names := make([]string, len(d.Fields))
+	for i, f := range d.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// FieldDesc the description of a field.
+type FieldDesc struct {
+	// Name the field name in the rules. eg: "Name", "Items.*.Sku"
+	Name string
+	// OutName the field output name, use for the Errors key. eg: "items.*.sku"
+	OutName string
+	// Label the field translate name in the messages, empty if not set.
+	Label string
+	// Optional the field is only validated on the value is not empty.
+	Optional bool
+	// Default the default value of the field, nil if not set.
+	Default any
+	// Filters the filter names of the field, with the args. eg: "substr:0,2"
+	Filters []string
+	// Scenes the scene names the field is included in.
+	Scenes []string
+	// Rules the validator rules of the field, in the order of applied.
+	Rules []*RuleDesc
+}
+
+// Validators returns the real validator names of the field rules,
+// include the elements and expression rules.
+func (f *FieldDesc) Validators() []string {
+	var names []string
+	var collect func(rules []*RuleDesc)
+	collect = func(rules []*RuleDesc) {
+		for _, r := range rules {
+			if len(r.Subs) > 0 {
+				collect(r.Subs)
+				continue
+			}
+			names = append(names, r.Name)
+		}
+	}
+	collect(f.Rules)
+	return names
+}
+
+// RuleDesc the description of a validator rule.
+type RuleDesc struct {
+	// Validator the validator name in the rule, may be an alias. eg: "minLen"
+	Validator string
+	// Name the real validator name. eg: "minLength"
+	Name string
+	// Args the rule arguments, converted to the validator args type if can.
+	// The expression rule arg is the expression text.
+	Args []any
+	// Scene the rule is only applied in the scene, empty for all scenes.
+	Scene string
+	// Optional the rule is skipped on the field value is empty.
+	Optional bool
+	// SkipEmpty the rule is skipped on the value is not exists or empty.
+	SkipEmpty bool
+	// Dive the dive depth, 0 is the field value, 1 is each element of
+	// the field value, and so on.
+	Dive int
+	// Key the rule is applied to each map key after the dive.
+	Key bool
+	// Message the effective error message of the rule. The element rules use
+	// the "*" as the element path. eg: "tags.* value must be in the enum [a b]"
+	Message string
+	// Subs the validator rules in the boolean expression. see RuleExprName
+	Subs []*RuleDesc
+}
+
+// Describe the rules, filters, labels and messages of the Validation.
+//
+// Usage:
+//
+//	v := validate.Map(data)
+//	v.StringRule("name", "required|minLen:2")
+//	for _, f := range v.Describe().Fields {
+//		fmt.Println(f.Name, f.Validators())
+//	}
+func (v *Validation) Describe() *Descriptor {
+	d := &Descriptor{}
+	if len(v.scenes) > 0 {
+		d.Scenes = make(SValues, len(v.scenes))
+		for scene, fields := range v.scenes {
+			d.Scenes[scene] = append([]string(nil), fields...)
+		}
+	}
+
+	index := make(map[string]*FieldDesc)
+	fieldOf := func(name string) *FieldDesc {
+		if f, ok := index[name]; ok {
+			return f
+		}
+
+		f := v.describeField(name)
+		index[name] = f
+		d.Fields = append(d.Fields, f)
+		return f
+	}
+
+	for _, r := range v.rules {
+		for _, field := range r.fields {
+			f := fieldOf(field)
+			f.Rules = append(f.Rules, v.describeRule(r, field)...)
+		}
+	}
+
+	for _, r := range v.filterRules {
+		for _, field := range r.fields {
+			f := fieldOf(field)
+			for i, name := range r.filters {
+				if args, ok := r.filterArgs[i]; ok {
+					name += ":" + args
+				}
+				f.Filters = append(f.Filters, name)
+			}
+		}
+	}
+
+	// the fields only has default value
+	fields := make([]string, 0, len(v.defValues))
+	for field := range v.defValues {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fieldOf(field)
+	}
+	return d
+}
+
+func (v *Validation) describeField(name string) *FieldDesc {
+	f := &FieldDesc{
+		Name:    name,
+		OutName: v.errKey(name),
+		Label:   v.trans.labelMap[name],
+		Default: v.defValues[name],
+	}
+	_, f.Optional = v.optionals[name]
+
+	for scene, fields := range v.scenes {
+		for _, field := range fields {
+			if field == name {
+				f.Scenes = append(f.Scenes, scene)
+				break
+			}
+		}
+	}
+	sort.Strings(f.Scenes)
+	return f
+}
+
+// describeRule describe the rule for the field. the dive rule is expanded
+// to the element rules.
+func (v *Validation) describeRule(r *Rule, field string) []*RuleDesc {
+	switch r.realName {
+	case RuleDive:
+		var list []*RuleDesc
+		path := field
+		for d, depth := r.diveRulesOf(v), 1; d != nil; d, depth = d.next, depth+1 {
+			path += ".*"
+			for _, kr := range d.keys {
+				rd := v.describeElemRule(r, kr, field, path)
+				rd.Dive, rd.Key = depth, true
+				list = append(list, rd)
+			}
+			for _, er := range d.elems {
+				rd := v.describeElemRule(r, er, field, path)
+				rd.Dive = depth
+				list = append(list, rd)
+			}
+		}
+		return list
+	case RuleExprName:
+		rd := v.newRuleDesc(r, r.errorArgs())
+		rd.Message = r.errorMessage(field, r.validator, v)
+		if e := r.ruleExprOf(v, field); e != nil {
+			rd.Subs = v.describeExpr(e, nil)
+		}
+		return []*RuleDesc{rd}
+	}
+
+	rd := v.newRuleDesc(r, v.describeArgs(r))
+	// render by the typed args, same as the runtime converted args.
+	cp := *r
+	cp.arguments = rd.Args
+	rd.Message = cp.errorMessage(field, r.validator, v)
+	return []*RuleDesc{rd}
+}
+
+// describeElemRule describe an element or map key rule of the dive rule p.
+func (v *Validation) describeElemRule(p, r *Rule, field, path string) *RuleDesc {
+	rd := &RuleDesc{
+		Validator: r.validator,
+		Name:      r.realName,
+		Scene:     p.scene,
+		Optional:  p.optional,
+		SkipEmpty: p.skipEmpty,
+	}
+
+	if r.realName == RuleExprName {
+		rd.Args = r.errorArgs()
+		rd.Subs = v.describeExpr(r.arguments[0].(*ruleExpr), nil)
+	} else {
+		rd.Args = v.describeArgs(r)
+	}
+	rd.Message = p.errorMessageAt(field, path, r.validator, rd.Args, v)
+	return rd
+}
+
+// describeExpr flatten the leaf rules of the expression.
+func (v *Validation) describeExpr(e *ruleExpr, list []*RuleDesc) []*RuleDesc {
+	if e.kind == exprLeaf {
+		return append(list, v.newRuleDesc(e.rule, v.describeArgs(e.rule)))
+	}
+	for _, sub := range e.subs {
+		list = v.describeExpr(sub, list)
+	}
+	return list
+}
+
+func (v *Validation) newRuleDesc(r *Rule, args []any) *RuleDesc {
+	return &RuleDesc{
+		Validator: r.validator,
+		Name:      r.realName,
+		Args:      args,
+		Scene:     r.scene,
+		Optional:  r.optional,
+		SkipEmpty: r.skipEmpty,
+	}
+}
+
+// describeArgs returns a copy of the rule args, converted to the validator
+// args type if can. the rule is not changed.
+func (v *Validation) describeArgs(r *Rule) []any {
+	if len(r.arguments) == 0 {
+		return nil
+	}
+
+	if r.realName == RuleEvalExpr {
+		return r.errorArgs()
+	}
+
+	args := make([]any, len(r.arguments))
+	copy(args, r.arguments)
+	if r.argsReady || !r.nameNotRequired {
+		return args
+	}
+
+	fm := r.checkFuncMeta
+	if fm == nil {
+		if v.data != nil {
+			fm = v.validatorMeta(r.realName)
+		} else if fm = v.validatorMetas[r.realName]; fm == nil {
+			fm = validatorMetas[r.realName]
+		}
+	}
+	if fm == nil || fm.style != styleLegacy {
+		return args
+	}
+
+	addNum := 1
+	if fm.fv.Type().In(0) == dataFaceType {
+		addNum++
+	}
+	if fm.checkArgNum(len(args)+addNum, r.validator) != nil {
+		return args
+	}
+
+	typed := make([]any, len(args))
+	copy(typed, args)
+	if convertRuleArgs(fm, "", typed, addNum) == nil {
+		return typed
+	}
+	return args
+}
+
+// DescribeStruct describe the rules of the struct type, collected same as
+// Struct(): the struct tags, ConfigValidation, Translates and Messages methods.
+// The s can be a struct value, pointer or reflect.Type.
+//
+// The slice or map of struct fields are described by the wildcard path.
+// eg: "Items.*.Sku"
+//
+// Returns the ConfigErrors on the rules is invalid, see Precompile.
+//
+// Usage:
+//
+//	d, err := validate.DescribeStruct(User{})
+//	f := d.Field("Name")
+func DescribeStruct(s any) (*Descriptor, error) {
+	rt, ok := structTypeOf(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: DescribeStruct the type %v is not a struct", ErrInvalidData, rt)
+	}
+
+	d := &StructData{
+		meta:        getTypeMeta(rt),
+		valueTyp:    rt,
+		ValidateTag: gOpt.ValidateTag,
+		FilterTag:   gOpt.FilterTag,
+		fieldNames:  make(map[string]int8),
+	}
+	d.value = filledValue(rt, map[reflect.Type]bool{rt: true})
+	d.src = d.value.Addr().Interface()
+
+	v := newEmpty()
+	v.data = d
+	v.CollectConfigErr = true
+	err := catchConfigPanic(v, func() {
+		d.parseRulesFromTag(v)
+		d.configInto(v)
+	})
+	if err == nil {
+		err = v.Precompile()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// use the wildcard for the filled elements. eg: "Items.0.Sku" -> "Items.*.Sku"
+	v.renameFields(wildcardPath, d.fieldNames)
+	if len(v.trans.fieldMap) > 0 {
+		fieldMap := make(map[string]string, len(v.trans.fieldMap))
+		for field, outName := range v.trans.fieldMap {
+			fieldMap[wildcardPath(field)] = wildcardPath(outName)
+		}
+		v.trans.fieldMap = fieldMap
+	}
+	return v.Describe(), nil
+}
+
+// wildcardPath replace the index 0 of the filled slice element with "*".
+func wildcardPath(path string) string {
+	if !strings.Contains(path, ".0") {
+		return path
+	}
+
+	nodes := strings.Split(path, ".")
+	for i, node := range nodes {
+		if node == "0" && i > 0 {
+			nodes[i] = "*"
+		}
+	}
+	return strings.Join(nodes, ".")
+}
+
+// Describe the rules of the RuleSet, see Validation.Describe
+func (rs *RuleSet) Describe() *Descriptor {
+	v := rs.createIn(defaultFactory, FromMap(M{}))
+	d := v.Describe()
+	v.Release()
+	return d
+}
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type descItem struct {
+	Sku string `json:"sku" validate:"required|minLen:3" label:"SKU"`
+}
+
+type descOrder struct {
+	Name   string            `json:"name" validate:"required|minLen:2" filter:"trim" message:"required:name is required"`
+	Age    int               `json:"age" validate:"optional|between:1,120"`
+	Email  string            `json:"email" validate:"(email or isCnMobile)"`
+	Tags   []string          `json:"tags" validate:"dive|in:go,php"`
+	Labels map[string]string `json:"labels" validate:"dive|keys|alpha|endkeys|required"`
+	Items  []descItem        `json:"items" validate:"required"`
+}
+
+func TestDescribeStruct(t *testing.T) {
+	is := assert.New(t)
+
+	d, err := DescribeStruct(&descOrder{})
+	is.NoErr(err)
+	is.Eq([]string{"Name", "Age", "Email", "Tags", "Labels", "Items", "Items.*.Sku"}, d.FieldNames())
+
+	f := d.Field("Name")
+	is.Eq("name", f.OutName)
+	is.Eq([]string{"trim"}, f.Filters)
+	is.Eq([]string{"required", "minLength"}, f.Validators())
+	is.Eq("name is required", f.Rules[0].Message)
+	is.Eq("minLen", f.Rules[1].Validator)
+	is.Eq([]any{2}, f.Rules[1].Args)
+	is.Eq("name min length is 2", f.Rules[1].Message)
+
+	f = d.Field("Age")
+	is.True(f.Optional)
+	is.True(f.Rules[0].Optional)
+	is.Eq([]any{"1", "120"}, f.Rules[1].Args)
+
+	// same message as the runtime
+	v := Struct(&descOrder{Name: "ab", Age: 200, Email: "a@b.cn", Items: []descItem{{Sku: "abc"}}})
+	is.False(v.Validate())
+	is.Eq(v.Errors.FieldOne("age"), f.Rules[1].Message)
+
+	f = d.Field("Email")
+	is.Len(f.Rules, 1)
+	is.Eq(RuleExprName, f.Rules[0].Name)
+	is.Eq([]any{"email or isCnMobile"}, f.Rules[0].Args)
+	is.Eq([]string{"isEmail", "isCnMobile"}, f.Validators())
+
+	f = d.Field("Tags")
+	is.Eq(1, f.Rules[0].Dive)
+	is.Eq([]any{[]string{"go", "php"}}, f.Rules[0].Args)
+	is.Eq("tags.* value must be in the enum [go php]", f.Rules[0].Message)
+
+	f = d.Field("Labels")
+	is.True(f.Rules[0].Key)
+	is.Eq("isAlpha", f.Rules[0].Name)
+	is.False(f.Rules[1].Key)
+
+	f = d.Field("Items.*.Sku")
+	is.Eq("items.*.sku", f.OutName)
+	is.Eq("SKU", f.Label)
+	is.Eq("SKU min length is 3", f.Rules[1].Message)
+
+	// the OutName is same as the Errors key
+	Config(func(opt *GlobalOption) { opt.ErrKeyFmt = ErrKeyOutputName })
+	defer ResetOption()
+	d, err = DescribeStruct(&errKeyUser{})
+	is.NoErr(err)
+	is.Eq("Address.zip_code", d.Field("Address.ZipCode").OutName)
+	es := Struct(&errKeyUser{}).ValidateE()
+	is.True(es.HasField(d.Field("Address.ZipCode").OutName))
+
+	_, err = DescribeStruct("invalid")
+	is.ErrIs(err, ErrInvalidData)
+	_, err = DescribeStruct(cfgErrUser{})
+	is.ErrIs(err, ErrConfig)
+}
+
+func TestValidation_Describe(t *testing.T) {
+	is := assert.New(t)
+
+	v := Map(M{"age": 10})
+	v.StringRule("age", "int|min:1", "int")
+	v.AddRule("name", "minLen", "2").SetScene("create")
+	v.WithScenes(SValues{"create": {"name"}, "update": {"name", "age"}})
+	v.SetDefValue("role", "user")
+	v.AddTranslates(MS{"age": "Age"})
+
+	d := v.Describe()
+	is.Eq([]string{"age", "name", "role"}, d.FieldNames())
+	is.Eq([]string{"name", "age"}, d.Scenes["update"])
+
+	f := d.Field("age")
+	is.Eq("Age", f.Label)
+	is.Eq([]string{"int"}, f.Filters)
+	is.Eq([]string{"update"}, f.Scenes)
+	is.Eq("Age min value is 1", f.Rules[1].Message)
+
+	f = d.Field("name")
+	is.Eq("create", f.Rules[0].Scene)
+	is.Eq([]any{2}, f.Rules[0].Args)
+	is.Eq([]string{"create", "update"}, f.Scenes)
+	// the rule args are not changed
+	is.Eq([]any{"2"}, v.rules[2].arguments)
+
+	is.Eq("user", d.Field("role").Default)
+	is.Nil(d.Field("notExists"))
+
+	// RuleSet
+	rs := MustRuleSet(NewRuleSet(MS{"tags": "required|dive|minLen:2"}))
+	d = rs.Describe()
+	is.Eq([]string{"required", "minLength"}, d.Field("tags").Validators())
+	is.Eq(1, d.Field("tags").Rules[1].Dive)
+}
@@ -0,0 +1,1307 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/validate/v2/internal/fieldval"
+)
+
+// RuleEvalExpr is the validator name of a field expression. The arg is an
+// expression over the data fields, the field is valid on it is true.
+//
+//	v.StringRule("end_at", "expr:end_at > start_at + duration(1h)")
+//	v.StringRule("discount", "expr:$ <= price * 0.5")
+//
+// The struct field can use the validate tag, or the expr tag after it is
+// enabled by GlobalOption.ExprTag:
+//
+//	EndAt time.Time `json:"end_at" validate:"expr:end_at > start_at + duration(1h)"`
+//	EndAt time.Time `json:"end_at" expr:"end_at > start_at + duration(1h)"`
+//
+// Grammar, the operators are listed by the precedence from low to high:
+//
+//	or, ||           logical or
+//	and, &&          logical and
+//	not, !           logical not
+//	== != < <= > >=  comparison
+//	+ -              add, sub. number, time +- duration, time - time
+//	* / %            mul, div, mod. number, duration * number
+//	-                negative
+//
+// The operands:
+//
+//   - field path: the data field, same as Validation.Get. eg: "user.age", "items.0.qty"
+//   - "$": the current field value, or the element value after "dive"
+//   - literals: 12, 1.5, 'str', "str", true, false, null, duration 1h30m, 7d
+//   - functions: len(x), now(), today(), duration(s), date(s), year(t),
+//     month(t), day(t), abs(x), trim(s), contains(s, sub), startsWith(s, prefix),
+//     endsWith(s, suffix), matches(s, 'regexp')
+//
+// The expression is parsed once when the rule is added, and type checked by the
+// field types of the struct data. For the untyped data (map, form, JSON), the
+// string value is converted to the type of the other operand at runtime. eg:
+// "2024-05-01" to a time, "12" to a number.
+//
+// The missing field is null: the arithmetic on null is null, the null is not
+// equal to any value except null, and the other comparisons are false.
+//
+// NOTE: "|" separates the rule segments, use "or" instead of "||" in the
+// validate tag, or use the expr tag.
+const RuleEvalExpr = "expr"
+
+// exprType the type set of an expression node. The field with an unknown type
+// is exprAny. At runtime a value has a single type, 0 on a runtime error. eg:
+// compare the string "abc" to a number.
+type exprType uint8
+
+const (
+	exprNull exprType = 1 << iota
+	exprBool
+	exprNum
+	exprStr
+	exprTime
+	exprDur
+	// slice, array or map. only can be used by len()
+	exprList
+
+	exprAny = exprNull | exprBool | exprNum | exprStr | exprTime | exprDur | exprList
+)
+
+var exprTypeNames = [...]string{"null", "bool", "number", "string", "time", "duration", "list"}
+
+// String returns the type names. eg: "null|number"
+func (t exprType) String() string {
+	if t == exprAny {
+		return "any"
+	}
+
+	var ss []string
+	for i, name := range exprTypeNames {
+		if t&(1<<i) != 0 {
+			ss = append(ss, name)
+		}
+	}
+	if len(ss) == 0 {
+		return "invalid"
+	}
+	return strings.Join(ss, "|")
+}
+
+// exprVal is a runtime value of the expression.
+type exprVal struct {
+	typ exprType
+	b   bool
+	num float64 // the number, or the length of the list
+	dur time.Duration
+	str string
+	tm  time.Time
+}
+
+func exprBoolVal(b bool) exprVal { return exprVal{typ: exprBool, b: b} }
+
+func exprNumVal(n float64) exprVal { return exprVal{typ: exprNum, num: n} }
+
+var (
+	nullExprVal  = exprVal{typ: exprNull}
+	durationType = reflect.TypeOf(time.Duration(0))
+	jsonNumType  = reflect.TypeOf(json.Number(""))
+	nilObjType   = reflect.TypeOf(NilObject{})
+)
+
+// exprValueOf convert the field value to the expression value, without boxing.
+func exprValueOf(val any) exprVal {
+	switch x := val.(type) {
+	case nil, NilObject:
+		return nullExprVal
+	case string:
+		return exprVal{typ: exprStr, str: x}
+	case bool:
+		return exprBoolVal(x)
+	case int:
+		return exprNumVal(float64(x))
+	case int64:
+		return exprNumVal(float64(x))
+	case float64:
+		return exprNumVal(x)
+	case time.Time:
+		return exprVal{typ: exprTime, tm: x}
+	case *time.Time:
+		if x == nil {
+			return nullExprVal
+		}
+		return exprVal{typ: exprTime, tm: *x}
+	case time.Duration:
+		return exprVal{typ: exprDur, dur: x}
+	}
+	return exprValueOfRV(reflect.ValueOf(val))
+}
+
+// exprValueOfRV convert the reflect value to the expression value.
+func exprValueOfRV(rv reflect.Value) exprVal {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nullExprVal
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nullExprVal
+	}
+
+	switch rv.Type() {
+	case timeType:
+		// the addressable struct field is read by the pointer, no boxing.
+		if rv.CanAddr() {
+			return exprVal{typ: exprTime, tm: *rv.Addr().Interface().(*time.Time)}
+		}
+		return exprVal{typ: exprTime, tm: rv.Interface().(time.Time)}
+	case durationType:
+		return exprVal{typ: exprDur, dur: time.Duration(rv.Int())}
+	case jsonNumType:
+		return exprCoerce(exprVal{typ: exprStr, str: rv.String()}, exprNum)
+	case nilObjType:
+		return nullExprVal
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return exprBoolVal(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return exprNumVal(float64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return exprNumVal(float64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return exprNumVal(rv.Float())
+	case reflect.String:
+		return exprVal{typ: exprStr, str: rv.String()}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return exprVal{typ: exprList, num: float64(rv.Len())}
+	}
+	return exprVal{}
+}
+
+// exprTypeOf get the expression type of the Go type, 0 if not supported.
+func exprTypeOf(rt reflect.Type) exprType {
+	var null exprType
+	for rt.Kind() == reflect.Ptr {
+		rt, null = rt.Elem(), exprNull
+	}
+
+	switch rt {
+	case timeType:
+		return exprTime | null
+	case durationType:
+		return exprDur | null
+	case jsonNumType:
+		return exprNum | null
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return exprBool | null
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return exprNum | null
+	case reflect.String:
+		return exprStr | null
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return exprList | null
+	case reflect.Interface:
+		return exprAny
+	}
+	return 0
+}
+
+// exprTimeLayouts the layouts to convert a string to the time.
+var exprTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// exprCoerce convert the string value to the type. returns the invalid value
+// on failed.
+func exprCoerce(x exprVal, typ exprType) exprVal {
+	s := strings.TrimSpace(x.str)
+	switch typ {
+	case exprNum:
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return exprNumVal(n)
+		}
+	case exprBool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return exprBoolVal(b)
+		}
+	case exprTime:
+		for _, layout := range exprTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return exprVal{typ: exprTime, tm: t}
+			}
+		}
+	case exprDur:
+		if d, err := parseExprDuration(s); err == nil {
+			return exprVal{typ: exprDur, dur: d}
+		}
+	case exprStr:
+		return x
+	}
+	return exprVal{}
+}
+
+// parseExprDuration parse the duration, support the day unit "d". eg: "7d", "1d12h"
+func parseExprDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if pos := strings.IndexByte(s, 'd'); pos > 0 {
+		n, err := strconv.ParseFloat(s[:pos], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		days, s = time.Duration(n*float64(24*time.Hour)), s[pos+1:]
+		if s == "" {
+			return days, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	return days + d, err
+}
+
+/*************************************************************
+ * the compiled expression
+ *************************************************************/
+
+// expression node operators
+type exprOp uint8
+
+const (
+	opLit exprOp = iota
+	opField
+	opSelf
+	opCall
+	opNeg
+	opNot
+	opAnd
+	opOr
+	opEq
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opMod
+)
+
+// exprOpType a valid operand types and the result type of a binary operator.
+type exprOpType struct {
+	l, r, res exprType
+}
+
+var exprCompareTypes = []exprOpType{
+	{exprNum, exprNum, exprBool},
+	{exprStr, exprStr, exprBool},
+	{exprTime, exprTime, exprBool},
+	{exprDur, exprDur, exprBool},
+}
+
+// exprOpTypes the valid operand types of the binary operators.
+var exprOpTypes = map[exprOp][]exprOpType{
+	opEq: append([]exprOpType{{exprBool, exprBool, exprBool}}, exprCompareTypes...),
+	opNe: append([]exprOpType{{exprBool, exprBool, exprBool}}, exprCompareTypes...),
+	opLt: exprCompareTypes,
+	opLe: exprCompareTypes,
+	opGt: exprCompareTypes,
+	opGe: exprCompareTypes,
+	opAdd: {
+		{exprNum, exprNum, exprNum}, {exprTime, exprDur, exprTime},
+		{exprDur, exprTime, exprTime}, {exprDur, exprDur, exprDur},
+	},
+	opSub: {
+		{exprNum, exprNum, exprNum}, {exprTime, exprDur, exprTime},
+		{exprTime, exprTime, exprDur}, {exprDur, exprDur, exprDur},
+	},
+	opMul: {{exprNum, exprNum, exprNum}, {exprDur, exprNum, exprDur}, {exprNum, exprDur, exprDur}},
+	opDiv: {{exprNum, exprNum, exprNum}, {exprDur, exprNum, exprDur}, {exprDur, exprDur, exprNum}},
+	opMod: {{exprNum, exprNum, exprNum}},
+}
+
+var exprOpNames = map[exprOp]string{
+	opEq: "==", opNe: "!=", opLt: "<", opLe: "<=", opGt: ">", opGe: ">=",
+	opAdd: "+", opSub: "-", opMul: "*", opDiv: "/", opMod: "%",
+}
+
+// expression functions
+type exprFn uint8
+
+const (
+	fnLen exprFn = iota + 1
+	fnNow
+	fnToday
+	fnDuration
+	fnDate
+	fnYear
+	fnMonth
+	fnDay
+	fnAbs
+	fnTrim
+	fnContains
+	fnStartsWith
+	fnEndsWith
+	fnMatches
+)
+
+// exprFunc the signature of an expression function.
+type exprFunc struct {
+	fn     exprFn
+	params []exprType
+	ret    exprType
+}
+
+var exprFuncs = map[string]exprFunc{
+	"len":        {fnLen, []exprType{exprStr | exprList}, exprNum},
+	"now":        {fnNow, nil, exprTime},
+	"today":      {fnToday, nil, exprTime},
+	"duration":   {fnDuration, []exprType{exprStr | exprDur}, exprDur},
+	"date":       {fnDate, []exprType{exprStr | exprTime}, exprTime},
+	"year":       {fnYear, []exprType{exprStr | exprTime}, exprNum},
+	"month":      {fnMonth, []exprType{exprStr | exprTime}, exprNum},
+	"day":        {fnDay, []exprType{exprStr | exprTime}, exprNum},
+	"abs":        {fnAbs, []exprType{exprNum | exprDur}, exprNum | exprDur},
+	"trim":       {fnTrim, []exprType{exprStr}, exprStr},
+	"contains":   {fnContains, []exprType{exprStr, exprStr}, exprBool},
+	"startsWith": {fnStartsWith, []exprType{exprStr, exprStr}, exprBool},
+	"endsWith":   {fnEndsWith, []exprType{exprStr, exprStr}, exprBool},
+	"matches":    {fnMatches, []exprType{exprStr, exprStr}, exprBool},
+}
+
+// exprNode is a node of the compiled expression. read-only after compiled.
+type exprNode struct {
+	op   exprOp
+	typ  exprType // the static type set
+	val  exprVal  // the literal value
+	path string   // the field path
+	fn   exprFn
+	re   *regexp.Regexp // the compiled pattern of matches()
+	args []*exprNode
+}
+
+// evalExpr is a compiled field expression. It is built once when the rule is
+// added and is read-only afterwards, so it is safe to share across Validation
+// instances (eg: by the static struct rule template).
+type evalExpr struct {
+	src  string
+	root *exprNode
+}
+
+// String returns the expression text, used in error messages.
+func (e *evalExpr) String() string { return e.src }
+
+// exprCtx the runtime context of the evaluation.
+type exprCtx struct {
+	v  *Validation
+	fv *fieldval.FieldValue // the "$" value
+}
+
+// eval the expression, true on the result is true.
+func (e *evalExpr) eval(v *Validation, fv *fieldval.FieldValue) bool {
+	c := exprCtx{v: v, fv: fv}
+	return e.root.eval(&c).logic().truthy()
+}
+
+func (n *exprNode) eval(c *exprCtx) exprVal {
+	switch n.op {
+	case opLit:
+		return n.val
+	case opField:
+		r := c.v.getFieldCarrier(n.path)
+		switch {
+		case r.useRV:
+			return exprValueOfRV(r.rv)
+		case r.exist || r.isDefault:
+			return exprValueOf(r.val)
+		}
+		return nullExprVal
+	case opSelf:
+		if c.fv == nil {
+			return nullExprVal
+		}
+		return exprValueOfRV(c.fv.RV())
+	case opCall:
+		return n.call(c)
+	case opNeg:
+		x := n.args[0].eval(c)
+		switch x.typ {
+		case exprNum:
+			x.num = -x.num
+		case exprDur:
+			x.dur = -x.dur
+		case exprStr:
+			if x = exprCoerce(x, exprNum); x.typ == exprNum {
+				x.num = -x.num
+			}
+		case exprNull:
+		default:
+			return exprVal{}
+		}
+		return x
+	case opNot:
+		x := n.args[0].eval(c).logic()
+		if x.typ == 0 {
+			return x
+		}
+		return exprBoolVal(!x.truthy())
+	case opAnd, opOr:
+		l := n.args[0].eval(c).logic()
+		if l.typ == 0 {
+			return l
+		}
+		// short-circuited
+		if l.truthy() == (n.op == opOr) {
+			return exprBoolVal(n.op == opOr)
+		}
+
+		r := n.args[1].eval(c).logic()
+		if r.typ == 0 {
+			return r
+		}
+		return exprBoolVal(r.truthy())
+	}
+
+	return n.binary(n.args[0].eval(c), n.args[1].eval(c))
+}
+
+// logic convert the string operand of the logical operators to a bool.
+// eg: the form value "true"
+func (x exprVal) logic() exprVal {
+	if x.typ == exprStr {
+		return exprCoerce(x, exprBool)
+	}
+	return x
+}
+
+// truthy the value is a true bool, the null is false.
+func (x exprVal) truthy() bool {
+	return x.typ == exprBool && x.b
+}
+
+// binary evaluate the binary operator.
+func (n *exprNode) binary(l, r exprVal) exprVal {
+	if l.typ == 0 || r.typ == 0 {
+		return exprVal{}
+	}
+
+	// convert the string to the other operand type. eg: "12" > 10
+	if l.typ == exprStr && r.typ != exprStr && r.typ != exprNull {
+		l = n.coerce(l, r.typ, true)
+	} else if r.typ == exprStr && l.typ != exprStr && l.typ != exprNull {
+		r = n.coerce(r, l.typ, false)
+	}
+
+	switch n.op {
+	case opEq, opNe:
+		// the string can't be converted is not equal
+		return exprBoolVal(l.typ != 0 && r.typ != 0 && l.equal(r) == (n.op == opEq))
+	}
+	if l.typ == 0 || r.typ == 0 {
+		return exprVal{}
+	}
+
+	switch n.op {
+	case opLt, opLe, opGt, opGe:
+		if l.typ != r.typ {
+			if l.typ == exprNull || r.typ == exprNull {
+				return exprBoolVal(false)
+			}
+			return exprVal{}
+		}
+
+		cmp, ok := l.compare(r)
+		if !ok {
+			return exprVal{}
+		}
+		switch n.op {
+		case opLt:
+			return exprBoolVal(cmp < 0)
+		case opLe:
+			return exprBoolVal(cmp <= 0)
+		case opGt:
+			return exprBoolVal(cmp > 0)
+		}
+		return exprBoolVal(cmp >= 0)
+	}
+
+	// arithmetic
+	if l.typ == exprNull || r.typ == exprNull {
+		return nullExprVal
+	}
+
+	switch lt, rt := l.typ, r.typ; {
+	case lt == exprNum && rt == exprNum:
+		switch n.op {
+		case opAdd:
+			return exprNumVal(l.num + r.num)
+		case opSub:
+			return exprNumVal(l.num - r.num)
+		case opMul:
+			return exprNumVal(l.num * r.num)
+		case opDiv:
+			return exprNumVal(l.num / r.num)
+		case opMod:
+			return exprNumVal(math.Mod(l.num, r.num))
+		}
+	case lt == exprTime && rt == exprDur:
+		switch n.op {
+		case opAdd:
+			return exprVal{typ: exprTime, tm: l.tm.Add(r.dur)}
+		case opSub:
+			return exprVal{typ: exprTime, tm: l.tm.Add(-r.dur)}
+		}
+	case lt == exprDur && rt == exprTime:
+		if n.op == opAdd {
+			return exprVal{typ: exprTime, tm: r.tm.Add(l.dur)}
+		}
+	case lt == exprTime && rt == exprTime:
+		if n.op == opSub {
+			return exprVal{typ: exprDur, dur: l.tm.Sub(r.tm)}
+		}
+	case lt == exprDur && rt == exprDur:
+		switch n.op {
+		case opAdd:
+			return exprVal{typ: exprDur, dur: l.dur + r.dur}
+		case opSub:
+			return exprVal{typ: exprDur, dur: l.dur - r.dur}
+		case opDiv:
+			return exprNumVal(float64(l.dur) / float64(r.dur))
+		}
+	case lt == exprDur && rt == exprNum:
+		switch n.op {
+		case opMul:
+			return exprVal{typ: exprDur, dur: time.Duration(float64(l.dur) * r.num)}
+		case opDiv:
+			return exprVal{typ: exprDur, dur: time.Duration(float64(l.dur) / r.num)}
+		}
+	case lt == exprNum && rt == exprDur:
+		if n.op == opMul {
+			return exprVal{typ: exprDur, dur: time.Duration(l.num * float64(r.dur))}
+		}
+	}
+	return exprVal{}
+}
+
+// coerce convert the string operand by the other operand type and the operator.
+// eg: "2024-05-01" + 1h is a time, "2024-05-01" - t is a time, t + "1h" is a duration.
+func (n *exprNode) coerce(x exprVal, other exprType, left bool) exprVal {
+	switch {
+	case n.op == opAdd && other == exprTime, n.op == opSub && other == exprTime && !left:
+		return exprCoerce(x, exprDur)
+	case n.op == opAdd && other == exprDur, n.op == opSub && other == exprDur && left:
+		if t := exprCoerce(x, exprTime); t.typ == exprTime {
+			return t
+		}
+		return exprCoerce(x, exprDur)
+	case n.op == opSub && other == exprTime:
+		return exprCoerce(x, exprTime)
+	}
+	return exprCoerce(x, other)
+}
+
+// equal check the values are equal, the different types are not equal.
+func (x exprVal) equal(y exprVal) bool {
+	if x.typ != y.typ {
+		return false
+	}
+
+	switch x.typ {
+	case exprNull:
+		return true
+	case exprBool:
+		return x.b == y.b
+	case exprTime:
+		return x.tm.Equal(y.tm)
+	}
+	cmp, ok := x.compare(y)
+	return ok && cmp == 0
+}
+
+// compare the values of the same type. returns false on not comparable.
+func (x exprVal) compare(y exprVal) (int, bool) {
+	switch x.typ {
+	case exprNum:
+		return cmpOrdered(x.num, y.num), true
+	case exprStr:
+		return strings.Compare(x.str, y.str), true
+	case exprTime:
+		return x.tm.Compare(y.tm), true
+	case exprDur:
+		return cmpOrdered(x.dur, y.dur), true
+	}
+	return 0, false
+}
+
+func cmpOrdered[T float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// call the function.
+func (n *exprNode) call(c *exprCtx) exprVal {
+	switch n.fn {
+	case fnNow:
+		return exprVal{typ: exprTime, tm: time.Now()}
+	case fnToday:
+		y, m, d := time.Now().Date()
+		return exprVal{typ: exprTime, tm: time.Date(y, m, d, 0, 0, 0, 0, time.Local)}
+	}
+
+	x := n.args[0].eval(c)
+	switch {
+	case x.typ == 0:
+		return x
+	case x.typ == exprNull:
+		if n.fn == fnLen {
+			return exprNumVal(0)
+		}
+		if n.typ == exprBool {
+			return exprBoolVal(false)
+		}
+		return x
+	}
+
+	switch n.fn {
+	case fnLen:
+		switch x.typ {
+		case exprStr:
+			return exprNumVal(float64(utf8.RuneCountInString(x.str)))
+		case exprList:
+			return exprNumVal(x.num)
+		}
+	case fnDuration:
+		if x.typ == exprStr {
+			return exprCoerce(x, exprDur)
+		}
+		if x.typ == exprDur {
+			return x
+		}
+	case fnDate, fnYear, fnMonth, fnDay:
+		if x.typ == exprStr {
+			x = exprCoerce(x, exprTime)
+		}
+		if x.typ != exprTime {
+			break
+		}
+		switch n.fn {
+		case fnYear:
+			return exprNumVal(float64(x.tm.Year()))
+		case fnMonth:
+			return exprNumVal(float64(x.tm.Month()))
+		case fnDay:
+			return exprNumVal(float64(x.tm.Day()))
+		}
+		return x
+	case fnAbs:
+		switch x.typ {
+		case exprNum:
+			return exprNumVal(math.Abs(x.num))
+		case exprDur:
+			return exprVal{typ: exprDur, dur: x.dur.Abs()}
+		}
+	case fnTrim:
+		if x.typ == exprStr {
+			return exprVal{typ: exprStr, str: strings.TrimSpace(x.str)}
+		}
+	case fnMatches:
+		if x.typ == exprStr {
+			return exprBoolVal(n.re.MatchString(x.str))
+		}
+	case fnContains, fnStartsWith, fnEndsWith:
+		y := n.args[1].eval(c)
+		if x.typ != exprStr || y.typ != exprStr {
+			if y.typ == exprNull {
+				return exprBoolVal(false)
+			}
+			break
+		}
+		switch n.fn {
+		case fnContains:
+			return exprBoolVal(strings.Contains(x.str, y.str))
+		case fnStartsWith:
+			return exprBoolVal(strings.HasPrefix(x.str, y.str))
+		}
+		return exprBoolVal(strings.HasSuffix(x.str, y.str))
+	}
+	return exprVal{}
+}
+
+/*************************************************************
+ * parse and type check the expression
+ *************************************************************/
+
+// EvalExprError is returned on parse or type check a bad field expression.
+type EvalExprError struct {
+	// Expr the expression text
+	Expr string
+	// Pos the byte offset of the error in Expr
+	Pos int
+	// Msg the error message
+	Msg string
+}
+
+// Error string
+func (e *EvalExprError) Error() string {
+	return fmt.Sprintf("invalid expression '%s' at col %d: %s", e.Expr, e.Pos+1, e.Msg)
+}
+
+// exprFieldTyper resolve the field path to the data path and the type.
+type exprFieldTyper func(path string) (string, exprType, error)
+
+// evalParser the parser of the field expression.
+type evalParser struct {
+	src string
+	pos int
+	// the current token
+	tok    string
+	tokPos int
+	// resolve the field path and type, nil for the untyped data.
+	typeOf exprFieldTyper
+	// the field of the rule, for the type of "$"
+	field string
+}
+
+// compileEvalExpr parse and type check the expression. typeOf resolve the
+// field path to the data path and the type, nil for the untyped data.
+func compileEvalExpr(src, field string, typeOf exprFieldTyper) (*evalExpr, error) {
+	p := &evalParser{src: src, typeOf: typeOf, field: field}
+	p.next()
+	if p.tok == "" {
+		return nil, p.errorf(0, "empty expression")
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf(p.tokPos, "unexpected %q", p.tok)
+	}
+	if root.typ&exprBool == 0 {
+		return nil, p.errorf(0, "the expression must be a bool, got %s", root.typ)
+	}
+	return &evalExpr{src: src, root: root}, nil
+}
+
+func (p *evalParser) errorf(pos int, format string, args ...any) error {
+	return &EvalExprError{Expr: p.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next read the next token to p.tok, "" on the end.
+func (p *evalParser) next() {
+	s := p.src
+	for p.pos < len(s) && (s[p.pos] == ' ' || s[p.pos] == '\t' || s[p.pos] == '\n' || s[p.pos] == '\r') {
+		p.pos++
+	}
+
+	start := p.pos
+	p.tokPos = start
+	if start >= len(s) {
+		p.tok = ""
+		return
+	}
+
+	c := s[start]
+	end := start + 1
+	switch {
+	case isExprIdentChar(c) && (c < '0' || c > '9'): // field path or name
+		for end < len(s) && (isExprIdentChar(s[end]) || s[end] == '.' && end+1 < len(s) && isExprIdentChar(s[end+1])) {
+			end++
+		}
+	case c >= '0' && c <= '9': // number or duration. eg: 12, 1.5, 1h30m
+		for end < len(s) && (isExprIdentChar(s[end]) || s[end] == '.') {
+			end++
+		}
+	case c == '\'' || c == '"': // string
+		for end < len(s) && s[end] != c {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		// the closing quote
+		end = min(end+1, len(s))
+	case strings.HasPrefix(s[start:], "=="), strings.HasPrefix(s[start:], "!="),
+		strings.HasPrefix(s[start:], "<="), strings.HasPrefix(s[start:], ">="),
+		strings.HasPrefix(s[start:], "&&"), strings.HasPrefix(s[start:], "||"):
+		end = start + 2
+	}
+
+	p.tok = s[start:end]
+	p.pos = end
+}
+
+func isExprIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// parseOr: and { ("or" | "||") and }
+func (p *evalParser) parseOr() (*exprNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && (p.tok == "or" || p.tok == "OR" || p.tok == "||") {
+		pos := p.tokPos
+		p.next()
+
+		var right *exprNode
+		if right, err = p.parseAnd(); err == nil {
+			left, err = p.logical(opOr, pos, left, right)
+		}
+	}
+	return left, err
+}
+
+// parseAnd: not { ("and" | "&&") not }
+func (p *evalParser) parseAnd() (*exprNode, error) {
+	left, err := p.parseNot()
+	for err == nil && (p.tok == "and" || p.tok == "AND" || p.tok == "&&") {
+		pos := p.tokPos
+		p.next()
+
+		var right *exprNode
+		if right, err = p.parseNot(); err == nil {
+			left, err = p.logical(opAnd, pos, left, right)
+		}
+	}
+	return left, err
+}
+
+// parseNot: ("not" | "!") not | compare
+func (p *evalParser) parseNot() (*exprNode, error) {
+	if p.tok != "not" && p.tok != "NOT" && p.tok != "!" {
+		return p.parseCompare()
+	}
+
+	pos := p.tokPos
+	p.next()
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if x.typ&(exprBool|exprNull) == 0 {
+		return nil, p.errorf(pos, "the operand of 'not' must be a bool, got %s", x.typ)
+	}
+	return p.fold(&exprNode{op: opNot, typ: exprBool, args: []*exprNode{x}}, pos)
+}
+
+func (p *evalParser) logical(op exprOp, pos int, left, right *exprNode) (*exprNode, error) {
+	name := "and"
+	if op == opOr {
+		name = "or"
+	}
+	for _, x := range []*exprNode{left, right} {
+		if x.typ&(exprBool|exprNull) == 0 {
+			return nil, p.errorf(pos, "the operands of '%s' must be a bool, got %s", name, x.typ)
+		}
+	}
+	return p.fold(&exprNode{op: op, typ: exprBool, args: []*exprNode{left, right}}, pos)
+}
+
+var exprCompareOps = map[string]exprOp{"==": opEq, "!=": opNe, "<": opLt, "<=": opLe, ">": opGt, ">=": opGe}
+
+// parseCompare: sum [ ("==" | "!=" | "<" | "<=" | ">" | ">=") sum ]
+func (p *evalParser) parseCompare() (*exprNode, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := exprCompareOps[p.tok]
+	if !ok {
+		return left, nil
+	}
+
+	pos := p.tokPos
+	p.next()
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return p.binary(op, pos, left, right)
+}
+
+// parseSum: product { ("+" | "-") product }
+func (p *evalParser) parseSum() (*exprNode, error) {
+	left, err := p.parseProduct()
+	for err == nil && (p.tok == "+" || p.tok == "-") {
+		op, pos := opAdd, p.tokPos
+		if p.tok == "-" {
+			op = opSub
+		}
+		p.next()
+
+		var right *exprNode
+		if right, err = p.parseProduct(); err == nil {
+			left, err = p.binary(op, pos, left, right)
+		}
+	}
+	return left, err
+}
+
+// parseProduct: unary { ("*" | "/" | "%") unary }
+func (p *evalParser) parseProduct() (*exprNode, error) {
+	left, err := p.parseUnary()
+	for err == nil && (p.tok == "*" || p.tok == "/" || p.tok == "%") {
+		op, pos := opMul, p.tokPos
+		switch p.tok {
+		case "/":
+			op = opDiv
+		case "%":
+			op = opMod
+		}
+		p.next()
+
+		var right *exprNode
+		if right, err = p.parseUnary(); err == nil {
+			left, err = p.binary(op, pos, left, right)
+		}
+	}
+	return left, err
+}
+
+// parseUnary: "-" unary | primary
+func (p *evalParser) parseUnary() (*exprNode, error) {
+	if p.tok != "-" {
+		return p.parsePrimary()
+	}
+
+	pos := p.tokPos
+	p.next()
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	typ := x.typ & (exprNum | exprDur | exprNull)
+	if typ&^exprNull == 0 {
+		return nil, p.errorf(pos, "the operand of '-' must be a number or duration, got %s", x.typ)
+	}
+	return p.fold(&exprNode{op: opNeg, typ: typ, args: []*exprNode{x}}, pos)
+}
+
+// binary check the operand types and build the binary node.
+func (p *evalParser) binary(op exprOp, pos int, left, right *exprNode) (*exprNode, error) {
+	l, r := left.typ, right.typ
+	var typ exprType
+	for _, t := range exprOpTypes[op] {
+		if l&t.l != 0 && r&t.r != 0 {
+			typ |= t.res
+		}
+	}
+
+	hasNull := (l|r)&exprNull != 0
+	switch op {
+	case opEq, opNe:
+		if hasNull {
+			typ |= exprBool
+		}
+	case opLt, opLe, opGt, opGe:
+	default:
+		if typ != 0 && hasNull {
+			typ |= exprNull
+		}
+	}
+
+	if typ == 0 {
+		return nil, p.errorf(pos, "invalid operation: %s %s %s", left.typ, exprOpNames[op], right.typ)
+	}
+	return p.fold(&exprNode{op: op, typ: typ, args: []*exprNode{left, right}}, pos)
+}
+
+// parsePrimary: literal | path | "$" | name "(" [expr {"," expr}] ")" | "(" expr ")"
+func (p *evalParser) parsePrimary() (*exprNode, error) {
+	tok, pos := p.tok, p.tokPos
+	if tok == "" {
+		return nil, p.errorf(pos, "unexpected end")
+	}
+	p.next()
+
+	switch c := tok[0]; {
+	case tok == "(":
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, p.errorf(p.tokPos, "missing ')'")
+		}
+		p.next()
+		return x, nil
+	case tok == "$":
+		typ := exprType(exprAny)
+		if p.typeOf != nil {
+			if _, t, err := p.typeOf(p.field); err == nil {
+				typ = t
+			}
+		}
+		return &exprNode{op: opSelf, typ: typ}, nil
+	case c == '\'' || c == '"':
+		s, ok := unquoteExprString(tok)
+		if !ok {
+			return nil, p.errorf(pos, "invalid string %s", tok)
+		}
+		return &exprNode{op: opLit, typ: exprStr, val: exprVal{typ: exprStr, str: s}}, nil
+	case c >= '0' && c <= '9':
+		if n, err := strconv.ParseFloat(tok, 64); err == nil {
+			return &exprNode{op: opLit, typ: exprNum, val: exprNumVal(n)}, nil
+		}
+		d, err := parseExprDuration(tok)
+		if err != nil {
+			return nil, p.errorf(pos, "invalid number or duration %q", tok)
+		}
+		return &exprNode{op: opLit, typ: exprDur, val: exprVal{typ: exprDur, dur: d}}, nil
+	case isExprIdentChar(c):
+		switch tok {
+		case "true", "false":
+			return &exprNode{op: opLit, typ: exprBool, val: exprBoolVal(tok == "true")}, nil
+		case "null", "nil":
+			return &exprNode{op: opLit, typ: exprNull, val: nullExprVal}, nil
+		}
+		if p.tok == "(" {
+			return p.parseCall(tok, pos)
+		}
+		return p.fieldNode(tok, pos)
+	}
+	return nil, p.errorf(pos, "unexpected %q", tok)
+}
+
+// fieldNode resolve the field path.
+func (p *evalParser) fieldNode(path string, pos int) (*exprNode, error) {
+	if p.typeOf == nil {
+		return &exprNode{op: opField, typ: exprAny, path: path}, nil
+	}
+
+	dataPath, typ, err := p.typeOf(path)
+	if err != nil {
+		return nil, p.errorf(pos, "%s", err.Error())
+	}
+	return &exprNode{op: opField, typ: typ, path: dataPath}, nil
+}
+
+// parseCall parse the function call, p.tok is "(".
+func (p *evalParser) parseCall(name string, pos int) (*exprNode, error) {
+	f, ok := exprFuncs[name]
+	if !ok {
+		return nil, p.errorf(pos, "unknown function '%s'", name)
+	}
+
+	var args []*exprNode
+	p.next()
+	for p.tok != ")" {
+		if len(args) > 0 {
+			if p.tok != "," {
+				return nil, p.errorf(p.tokPos, "missing ',' or ')' in the function '%s'", name)
+			}
+			p.next()
+		}
+
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, x)
+	}
+	p.next()
+
+	if len(args) != len(f.params) {
+		return nil, p.errorf(pos, "the function '%s' wants %d args, got %d", name, len(f.params), len(args))
+	}
+
+	n := &exprNode{op: opCall, fn: f.fn, typ: f.ret, args: args}
+	for i, x := range args {
+		if x.typ&(f.params[i]|exprNull) == 0 {
+			return nil, p.errorf(pos, "the function '%s' arg %d wants %s, got %s", name, i+1, f.params[i], x.typ)
+		}
+		if x.typ&exprNull != 0 && f.ret != exprBool && f.fn != fnLen {
+			n.typ |= exprNull
+		}
+	}
+
+	switch f.fn {
+	case fnAbs:
+		n.typ &= args[0].typ | exprNull
+	case fnMatches:
+		if args[1].op != opLit || args[1].typ != exprStr {
+			return nil, p.errorf(pos, "the pattern of 'matches' must be a string literal")
+		}
+		re, err := regexp.Compile(args[1].val.str)
+		if err != nil {
+			return nil, p.errorf(pos, "invalid pattern: %s", err.Error())
+		}
+		n.re = re
+	case fnNow, fnToday:
+		return n, nil
+	}
+	return p.fold(n, pos)
+}
+
+// fold evaluate the node with the literal operands on compile. eg: duration('1h')
+func (p *evalParser) fold(n *exprNode, pos int) (*exprNode, error) {
+	for _, x := range n.args {
+		if x.op != opLit {
+			return n, nil
+		}
+	}
+
+	val := n.eval(nil)
+	if val.typ == 0 {
+		return nil, p.errorf(pos, "invalid constant operation")
+	}
+	return &exprNode{op: opLit, typ: val.typ, val: val}, nil
+}
+
+// unquoteExprString unquote the string literal, quoted by "'" or '"'. Only the
+// quote and "\\" are escaped, the other "\" are kept. eg: '^a\.b$'
+func unquoteExprString(s string) (string, bool) {
+	if len(s) < 2 || s[len(s)-1] != s[0] {
+		return "", false
+	}
+
+	quote, s := s[0], s[1:len(s)-1]
+	if strings.IndexByte(s, '\\') < 0 {
+		return s, true
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && (s[i+1] == quote || s[i+1] == '\\') {
+			i++
+			c = s[i]
+		} else if c == '\\' && i+1 == len(s) {
+			// the closing quote is escaped
+			return "", false
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), true
+}
+
+/*************************************************************
+ * the expression of the validation
+ *************************************************************/
+
+// structExprTyper resolve the field path of the struct type. The path can
+// use the field name or the output name by the FieldTag. eg: "EndAt", "end_at"
+func structExprTyper(rt reflect.Type) exprFieldTyper {
+	return func(path string) (string, exprType, error) {
+		typ := rt
+		segs := strings.Split(path, ".")
+		for i, seg := range segs {
+			for typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+
+			switch typ.Kind() {
+			case reflect.Struct:
+				sf, ok := exprStructField(typ, seg)
+				if !ok {
+					return "", 0, fmt.Errorf("unknown field '%s'", strings.Join(segs[:i+1], "."))
+				}
+				segs[i], typ = sf.Name, sf.Type
+			case reflect.Slice, reflect.Array:
+				if _, err := strconv.Atoi(seg); err != nil {
+					return "", 0, fmt.Errorf("the index '%s' of the field '%s' must be a number", seg, strings.Join(segs[:i], "."))
+				}
+				typ = typ.Elem()
+			case reflect.Map:
+				typ = typ.Elem()
+			case reflect.Interface:
+				return strings.Join(segs, "."), exprAny, nil
+			default:
+				return "", 0, fmt.Errorf("unknown field '%s'", strings.Join(segs[:i+1], "."))
+			}
+		}
+
+		et := exprTypeOf(typ)
+		if et == 0 {
+			return "", 0, fmt.Errorf("the type %s of the field '%s' is not supported", typ, path)
+		}
+		return strings.Join(segs, "."), et, nil
+	}
+}
+
+// exprStructField find the struct field by the name or output name.
+func exprStructField(rt reflect.Type, name string) (reflect.StructField, bool) {
+	if sf, ok := rt.FieldByName(name); ok {
+		return sf, true
+	}
+	if sf, ok := rt.FieldByName(strutil.UpperFirst(name)); ok {
+		return sf, true
+	}
+
+	if gOpt.FieldTag != "" {
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			if outName, _, _ := strings.Cut(sf.Tag.Get(gOpt.FieldTag), ","); outName == name {
+				return sf, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// compileEvalExpr compile the expression of the field. The struct data is type
+// checked by the field types, the static struct type compiles it once on build
+// the rule template.
+func (v *Validation) compileEvalExpr(field, src string) (*evalExpr, error) {
+	d, ok := v.data.(*StructData)
+	if !ok || d.valueTyp == nil {
+		return compileEvalExpr(src, field, nil)
+	}
+	return compileEvalExpr(src, field, structExprTyper(d.valueTyp))
+}
+
+// addEvalExpr compile and add the expression rule of the field.
+func (v *Validation) addEvalExpr(field, validator, src string) {
+	e, err := v.compileEvalExpr(field, src)
+	if err != nil {
+		v.configErrorf(field, RuleEvalExpr, "%s", err.Error())
+		return
+	}
+	v.addOneRule(field, validator, RuleEvalExpr, []any{e})
+}
+
+// EvalExpr validator: the field expression is true. It is bound as the "expr"
+// validator, expr is the compiled expression or the expression string.
+// see RuleEvalExpr
+func (v *Validation) EvalExpr(val any, expr any) bool {
+	return v.evalFieldExpr("", fieldval.New("", val), expr)
+}
+
+// evalFieldExpr evaluate the expression on the field value carrier.
+func (v *Validation) evalFieldExpr(field string, fv *fieldval.FieldValue, expr any) bool {
+	switch e := expr.(type) {
+	case *evalExpr:
+		return e.eval(v, fv)
+	case string:
+		ce, err := v.compileEvalExpr(field, e)
+		if err != nil {
+			v.configErrorf(field, RuleEvalExpr, "%s", err.Error())
+			return true
+		}
+		return ce.eval(v, fv)
+	}
+
+	v.configErrorf(field, RuleEvalExpr, "the validator '%s' requires an expression argument", RuleEvalExpr)
+	return true
+}
@@ -0,0 +1,237 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/x/assert"
+	"github.com/gookit/validate/v2/internal/fieldval"
+)
+
+func TestEvalExpr_map(t *testing.T) {
+	is := assert.New(t)
+
+	newV := func(data M) *Validation {
+		v := Map(data)
+		v.StringRule("end_at", "required|expr:end_at > start_at + duration(1h)")
+		v.StringRule("items", "expr:len(items) <= max_items")
+		v.StringRule("discount", "expr:$ <= price * 0.5")
+		return v
+	}
+
+	v := newV(M{
+		"start_at": "2024-05-01T10:00:00Z", "end_at": "2024-05-01T12:00:00Z",
+		"items": []any{"a", "b"}, "max_items": 2, "price": "100", "discount": 50,
+	})
+	is.True(v.Validate())
+
+	v = newV(M{"start_at": "2024-05-01 10:00:00", "end_at": "2024-05-01 10:30:00"})
+	is.False(v.Validate())
+	is.Eq("end_at did not satisfy the expression: end_at > start_at + duration(1h)", v.Errors.One())
+
+	v = newV(M{"end_at": "2024-05-02", "start_at": "2024-05-01", "items": []int{1, 2, 3}, "max_items": "2"})
+	is.False(v.Validate())
+	is.Eq("items did not satisfy the expression: len(items) <= max_items", v.Errors.One())
+
+	v = newV(M{"end_at": "2024-05-02", "start_at": "2024-05-01", "discount": 60.5, "price": 120})
+	is.False(v.Validate())
+	is.Contains(v.Errors, "discount")
+
+	// the missing field is null
+	v = newV(M{"end_at": "2024-05-02"})
+	is.False(v.Validate())
+	is.Contains(v.Errors, "end_at")
+
+	tests := []struct {
+		expr string
+		ok   bool
+	}{
+		{"age >= 18 and age < 60", true},
+		{"age > 18 && !(age == 30)", true},
+		{"not (age == 30) or name == 'tom'", true},
+		{"age % 2 == 0 and -age < 0", true},
+		{"age == 30 || name != 'tom'", false},
+		{"nick == null and nick != 'x'", true},
+		{"nick + 1 > 0", false},
+		{"name == 12", false},
+		{"name > 12", false},
+		{"contains(name, 'o') and startsWith(name, 't') and endsWith(name, 'm')", true},
+		{"matches(email, '^[a-z]+@example\\.com$')", true},
+		{"len(name) == 3 and len(tags) == 2 and len(nick) == 0 and len(trim(' ab ')) == 2", true},
+		{"year(born) == 2000 and month(born) == 2 and day(born) == 29", true},
+		{"date(born) < today() and born < now() - 7d", true},
+		{"duration('90m') == 1h30m and abs(-2) == 2 and abs(-1h) == 60m", true},
+		{"timeout / 2 == 15s and timeout * 2 >= 1m and 2 * timeout >= 60s", true},
+		{"end - born > 24h and end - born == duration('8760h')", false},
+		{"user.age > 10 and user.roles.0 == 'admin'", true},
+		{"active and score >= 9.5", true},
+		{"active == true and active != false", true},
+	}
+
+	data := M{
+		"age": 20, "name": "tom", "email": "tom@example.com", "tags": []string{"a", "b"},
+		"born": "2000-02-29", "end": time.Date(2001, 2, 28, 0, 0, 0, 0, time.UTC), "timeout": 30 * time.Second,
+		"user": map[string]any{"age": 12, "roles": []string{"admin"}}, "active": "true", "score": "9.5",
+	}
+	for _, tt := range tests {
+		v := Map(data)
+		v.StringRule("age", "expr:"+tt.expr)
+		is.Eq(tt.ok, v.Validate(), tt.expr)
+	}
+}
+
+type exprOrder struct {
+	StartAt  time.Time  `json:"start_at"`
+	EndAt    time.Time  `json:"end_at" expr:"end_at > start_at + duration(1h)"`
+	PaidAt   *time.Time `json:"paid_at" validate:"expr:$ >= StartAt or $ == null"`
+	Price    float64    `json:"price"`
+	Discount float64    `json:"discount" validate:"expr:discount <= price * 0.5" message:"expr:discount is too large"`
+	Items    []string   `json:"items" validate:"expr:len(items) <= MaxItems|dive|expr:len($) > 1"`
+	MaxItems int        `json:"max_items"`
+}
+
+func TestEvalExpr_struct(t *testing.T) {
+	is := assert.New(t)
+
+	// the expr tag of other libraries is ignored by default
+	type otherTag struct {
+		Price float64 `expr:"SUM(price)"`
+	}
+	is.NoErr(Struct(&otherTag{}).ValidateErr())
+
+	Config(func(opt *GlobalOption) { opt.ExprTag = "expr" })
+	defer ResetOption()
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	o := &exprOrder{
+		StartAt: start, EndAt: start.Add(2 * time.Hour), Price: 100, Discount: 50,
+		Items: []string{"ab", "cd"}, MaxItems: 2,
+	}
+	is.NoErr(Struct(o).ValidateErr())
+
+	o.EndAt = start.Add(time.Hour)
+	v := Struct(o)
+	is.False(v.Validate())
+	is.Eq("end_at did not satisfy the expression: end_at > start_at + duration(1h)", v.Errors.One())
+
+	o.EndAt, o.Discount = start.Add(2*time.Hour), 60
+	is.Eq("discount is too large", Struct(o).ValidateE().One())
+
+	o.Discount, o.Items = 10, []string{"ab", "c"}
+	v = Struct(o)
+	is.False(v.Validate())
+	is.Contains(v.Errors, "items.1")
+
+	o.Items = []string{"ab", "cd", "ef"}
+	is.Eq("items did not satisfy the expression: len(items) <= MaxItems", Struct(o).ValidateE().One())
+
+	o.Items, o.PaidAt = nil, &start
+	is.NoErr(Struct(o).ValidateErr())
+	paid := start.Add(-time.Minute)
+	o.PaidAt = &paid
+	is.Eq("paid_at did not satisfy the expression: $ >= StartAt or $ == null", Struct(o).ValidateE().One())
+
+	// the rules are described with the expression text
+	d := Struct(o).Describe().Field("Discount")
+	is.Eq([]any{"discount <= price * 0.5"}, d.Rules[0].Args)
+	is.Eq("discount is too large", d.Rules[0].Message)
+
+	// type checked by the struct field types
+	_, err := CompileRuleSet(func(v *Validation) {
+		v.StringRule("a", "expr:a > 'x' and (b == or c)")
+		v.StringRule("b", "expr:b + 1")
+	})
+	is.ErrIs(err, ErrConfig)
+	is.Len(err.(ConfigErrors), 2)
+
+	type badOrder struct {
+		StartAt time.Time `expr:"StartAt > 10"`
+		Price   float64   `expr:"Price > Discount"`
+		Name    string    `validate:"expr:contains(Price, 'a')"`
+		Note    string    `validate:"expr:Note == 1 or Note == null"`
+	}
+	err = Precompile(badOrder{})
+	is.ErrIs(err, ErrConfig)
+
+	errs := err.(ConfigErrors)
+	is.Len(errs, 4)
+	is.Eq("StartAt", errs[0].Field)
+	is.Eq(RuleEvalExpr, errs[0].Validator)
+	is.StrContains(errs[0].Error(), "invalid expression 'StartAt > 10' at col 9: invalid operation: time > number")
+	is.StrContains(errs[1].Error(), "at col 9: unknown field 'Discount'")
+	is.StrContains(errs[2].Error(), "the function 'contains' arg 1 wants string, got number")
+	// the typed field is not converted
+	is.StrContains(errs[3].Error(), "at col 6: invalid operation: string == number")
+}
+
+func TestEvalExpr_value(t *testing.T) {
+	is := assert.New(t)
+
+	is.NoErr(Val(4, "required|expr:$ > 3 and $ % 2 == 0"))
+	is.ErrMsg(Val(5, "expr:$ % 2 == 0"), "input did not satisfy the expression: $ % 2 == 0")
+	is.NoErr(Val([]int{1, 2}, "dive|expr:$ > 0"))
+
+	// the bad expressions
+	tests := []struct{ expr, err string }{
+		{"", "at col 1: empty expression"},
+		{"a >", "at col 4: unexpected end"},
+		{"a > 1)", "at col 6: unexpected \")\""},
+		{"(a > 1", "at col 7: missing ')'"},
+		{"a = 1", "at col 3: unexpected \"=\""},
+		{"a > 1x", "at col 5: invalid number or duration \"1x\""},
+		{"a > 'x", "at col 5: invalid string 'x"},
+		{"a + 1", "at col 1: the expression must be a bool, got null|number"},
+		{"a > 1 and 2", "at col 7: the operands of 'and' must be a bool, got number"},
+		{"not 1", "at col 1: the operand of 'not' must be a bool, got number"},
+		{"-'x' == a", "at col 1: the operand of '-' must be a number or duration, got string"},
+		{"1h > 'x' + 1", "at col 10: invalid operation: string + number"},
+		{"foo(a)", "at col 1: unknown function 'foo'"},
+		{"len(a, b) > 1", "at col 1: the function 'len' wants 1 args, got 2"},
+		{"len(a b) > 1", "at col 7: missing ',' or ')' in the function 'len'"},
+		{"len(1) > 1", "at col 1: the function 'len' arg 1 wants string|list, got number"},
+		{"matches(a, b)", "at col 1: the pattern of 'matches' must be a string literal"},
+		{"matches(a, '(')", "at col 1: invalid pattern: error parsing regexp"},
+		{"date('x') > a", "at col 1: invalid constant operation"},
+	}
+	for _, tt := range tests {
+		_, err := compileEvalExpr(tt.expr, "", nil)
+		is.Err(err, tt.expr)
+		if err != nil {
+			is.StrContains(err.Error(), tt.err, tt.expr)
+		}
+	}
+
+	// the tag linter checks the syntax
+	items, err := ParseTagRule("required|expr:a > b + 1")
+	is.NoErr(err)
+	is.Eq(TagRule{Validator: "expr", Name: RuleEvalExpr, Args: []any{"a > b + 1"}}, items[1])
+	_, err = ParseTagRule("expr:a >")
+	is.ErrMsg(err, "invalid expression 'a >' at col 4: unexpected end")
+}
+
+func TestEvalExpr_noAlloc(t *testing.T) {
+	is := assert.New(t)
+
+	o := &exprOrder{StartAt: time.Now(), Price: 100, Discount: 50, Items: []string{"a"}, MaxItems: 2}
+	o.EndAt = o.StartAt.Add(2 * time.Hour)
+	v := Struct(o)
+
+	e, err := v.compileEvalExpr("EndAt", "$ > start_at + duration(1h) and discount <= price * 0.5 and len(items) <= max_items")
+	is.NoErr(err)
+
+	fv := fieldval.New("EndAt", o.EndAt)
+	is.True(e.eval(v, fv))
+	is.Eq(0.0, testing.AllocsPerRun(100, func() {
+		e.eval(v, fv)
+	}))
+
+	m := Map(M{"price": 100, "discount": 30.5, "code": "A-12"})
+	e, err = m.compileEvalExpr("discount", "$ <= price * 0.5 and matches(code, '^A-[0-9]+$')")
+	is.NoErr(err)
+
+	fv = fieldval.New("discount", 30.5)
+	is.True(e.eval(m, fv))
+	is.Eq(0.0, testing.AllocsPerRun(100, func() {
+		e.eval(m, fv)
+	}))
+}
@@ -1,19 +1,43 @@
 package validate
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/gookit/goutil/strutil"
 	"github.com/gookit/validate/v2/internal/fieldval"
+	"github.com/gookit/validate/v2/internal/reflectx"
 )
 
 // FieldCtx 是传给(自定义)校验器的字段级上下文句柄:不装箱地拿到字段值(reflect.Value)、
 // 字段名/路径与规则参数。命名见 RFC §12 D6(不跟随 go-playground 的 FieldLevel)。
+//
+// Parent/Root/Sibling/Index/Key 按字段的具体路径从校验数据的根值反射解析(惰性, 仅调用时计算),
+// 支持嵌套结构体、切片/map 元素以及 ".*" 通配展开的元素。
 type FieldCtx interface {
 	Value() reflect.Value  // 去指针后的字段值(= 内部 RealV)
 	Raw() reflect.Value    // 原始 reflect.Value(= 内部 RV)
 	FieldName() string     // 字段路径,如 "addr.city"
 	Arg(i int) (any, bool) // 规则第 i 个参数
 	Args() []any           // 全部规则参数
+	// Context 本次校验的 context, 见 ValidateCtx。未设置时为 context.Background()
+	Context() context.Context
+
+	// Path 字段的具体路径, 通配元素已替换为下标。如 "Items.*.Qty" -> "Items.1.Qty"
+	Path() string
+	// Root 校验数据的根值(结构体/map/url.Values), 已去指针
+	Root() reflect.Value
+	// Parent 直接包含该字段的值(结构体/map/切片), 已去指针。顶层字段即 Root
+	Parent() reflect.Value
+	// Sibling 取 Parent 中名为 name 的字段(结构体字段名或 map 键), 已去指针
+	Sibling(name string) (reflect.Value, bool)
+	// Index 路径上最近一级切片/数组元素的下标
+	Index() (int, bool)
+	// Key 路径上最近一级 map 元素的键
+	Key() (any, bool)
 }
 
 // fieldCtx 是 FieldCtx 的内部实现,由值载体 *fieldval.FieldValue + 字段名 + 规则参数支撑。
@@ -21,6 +45,18 @@ type fieldCtx struct {
 	fv    *fieldval.FieldValue
 	field string
 	args  []any
+
+	// v 用于惰性解析路径上下文; path 为 ".*" 通配元素的具体路径, 非通配时为空(= field)
+	v    *Validation
+	path string
+
+	// 以下由 resolve() 惰性填充
+	resolved bool
+	root     reflect.Value
+	parent   reflect.Value
+	index    int
+	hasIndex bool
+	key      reflect.Value
 }
 
 var _ FieldCtx = (*fieldCtx)(nil)
@@ -35,3 +71,177 @@ func (c *fieldCtx) Arg(i int) (any, bool) {
 	return nil, false
 }
 func (c *fieldCtx) Args() []any { return c.args }
+
+func (c *fieldCtx) Context() context.Context {
+	if c.v == nil {
+		return context.Background()
+	}
+	return c.v.Context()
+}
+
+func (c *fieldCtx) Path() string {
+	c.resolve()
+	return c.path
+}
+
+func (c *fieldCtx) Root() reflect.Value {
+	c.resolve()
+	return c.root
+}
+
+func (c *fieldCtx) Parent() reflect.Value {
+	c.resolve()
+	return c.parent
+}
+
+func (c *fieldCtx) Sibling(name string) (reflect.Value, bool) {
+	c.resolve()
+	if !c.parent.IsValid() {
+		return emptyValue, false
+	}
+
+	sv, ok := childValue(c.parent, name)
+	if !ok {
+		return emptyValue, false
+	}
+	sv = reflectx.RemoveValuePtr(indirectInterface(sv))
+	return sv, sv.IsValid()
+}
+
+func (c *fieldCtx) Index() (int, bool) {
+	c.resolve()
+	return c.index, c.hasIndex
+}
+
+func (c *fieldCtx) Key() (any, bool) {
+	c.resolve()
+	if c.key.IsValid() && c.key.CanInterface() {
+		return c.key.Interface(), true
+	}
+	return nil, false
+}
+
+// resolve 按具体路径从根值逐段解析, 记录父值与最近一级的切片下标/map 键。
+func (c *fieldCtx) resolve() {
+	if c.resolved {
+		return
+	}
+	c.resolved = true
+	if c.path == "" {
+		c.path = c.field
+	}
+	if c.v == nil || c.v.data == nil {
+		return
+	}
+
+	c.root = dataRootValue(c.v.data)
+
+	cur := c.root
+	nodes := strings.Split(c.path, ".")
+	for i, node := range nodes {
+		if i == len(nodes)-1 {
+			c.parent = cur
+		}
+
+		switch cur.Kind() {
+		case reflect.Slice, reflect.Array:
+			if idx, err := strconv.Atoi(node); err == nil {
+				c.index, c.hasIndex = idx, true
+			}
+		case reflect.Map:
+			c.key, _ = mapKeyOf(cur, node)
+		}
+
+		next, ok := childValue(cur, node)
+		if !ok {
+			return
+		}
+		cur = reflectx.RemoveValuePtr(indirectInterface(next))
+	}
+}
+
+// dataRootValue 校验数据源的根值, 已去指针
+func dataRootValue(data DataFace) reflect.Value {
+	switch d := data.(type) {
+	case *StructData:
+		return d.value
+	case *MapData:
+		return reflect.ValueOf(d.Map)
+	case *FormData:
+		return reflect.ValueOf(d.Form)
+	}
+	return reflectx.RemoveValuePtr(reflect.ValueOf(data.Src()))
+}
+
+// childValue 取结构体字段/切片元素/map 值。rv 需已去指针
+func childValue(rv reflect.Value, node string) (reflect.Value, bool) {
+	var sub reflect.Value
+	switch rv.Kind() {
+	case reflect.Struct:
+		if sub = rv.FieldByName(node); !sub.IsValid() {
+			sub = rv.FieldByName(strutil.UpperFirst(node))
+		}
+	case reflect.Slice, reflect.Array:
+		idx, err := strconv.Atoi(node)
+		if err != nil || idx < 0 || idx >= rv.Len() {
+			return emptyValue, false
+		}
+		sub = rv.Index(idx)
+	case reflect.Map:
+		if key, ok := mapKeyOf(rv, node); ok {
+			sub = rv.MapIndex(key)
+		}
+	}
+	return sub, sub.IsValid()
+}
+
+// mapKeyOf 将路径节点转换为 map 的键值
+func mapKeyOf(rv reflect.Value, node string) (reflect.Value, bool) {
+	kt := rv.Type().Key()
+	if kt.Kind() == reflect.String {
+		return reflect.ValueOf(node).Convert(kt), true
+	}
+
+	for _, key := range rv.MapKeys() {
+		if fmt.Sprint(key.Interface()) == node {
+			return key, true
+		}
+	}
+	return emptyValue, false
+}
+
+// wildcardPaths 展开含 ".*" 的路径为存在的具体路径, 顺序与通配取值(展平后)一致。
+// eg: "Items.*.Qty" -> ["Items.0.Qty", "Items.1.Qty"]
+func wildcardPaths(root reflect.Value, field string) (paths []string) {
+	var walk func(rv reflect.Value, nodes []string, prefix string)
+	walk = func(rv reflect.Value, nodes []string, prefix string) {
+		if len(nodes) == 0 {
+			paths = append(paths, prefix)
+			return
+		}
+
+		rv = reflectx.RemoveValuePtr(indirectInterface(rv))
+		if nodes[0] == "*" {
+			if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+				for i := 0; i < rv.Len(); i++ {
+					walk(rv.Index(i), nodes[1:], joinPath(prefix, strconv.Itoa(i)))
+				}
+			}
+			return
+		}
+
+		if sub, ok := childValue(rv, nodes[0]); ok {
+			walk(sub, nodes[1:], joinPath(prefix, nodes[0]))
+		}
+	}
+
+	walk(root, strings.Split(field, "."), "")
+	return
+}
+
+func joinPath(prefix, node string) string {
+	if prefix == "" {
+		return node
+	}
+	return prefix + "." + node
+}
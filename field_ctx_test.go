@@ -163,3 +163,139 @@ func TestFieldCtxValidator_wildcardSlice(t *testing.T) {
 	v2.AddRule("tags.*", "nonEmptyFC")
 	assert.False(t, v2.Validate())
 }
+
+type fcLine struct {
+	Product string
+	Qty     int `validate:"qtyInStock"`
+	Stock   int
+}
+
+type fcAddr struct {
+	City string `validate:"cityInCountry"`
+}
+
+type fcOrder struct {
+	Country string
+	Lines   []fcLine          `validate:"slice"`
+	Ship    fcAddr            `validate:"required"`
+	ByCode  map[string]fcLine `validate:"required"`
+}
+
+func TestFieldCtx_parentAndSibling(t *testing.T) {
+	is := assert.New(t)
+
+	var paths []string
+	var indexes []int
+	var keys []any
+	qtyInStock := func(fc FieldCtx) bool {
+		paths = append(paths, fc.Path())
+		if idx, ok := fc.Index(); ok {
+			indexes = append(indexes, idx)
+		}
+		if key, ok := fc.Key(); ok {
+			keys = append(keys, key)
+		}
+
+		stock, ok := fc.Sibling("Stock")
+		is.Eq(reflect.Struct, fc.Parent().Kind())
+		return ok && fc.Value().Int() <= stock.Int()
+	}
+	cityInCountry := func(fc FieldCtx) bool {
+		is.Eq("Ship.City", fc.Path())
+		_, ok := fc.Index()
+		is.False(ok)
+		country := fc.Root().FieldByName("Country").String()
+		return country != "CN" || fc.Value().String() == "Beijing"
+	}
+
+	o := &fcOrder{
+		Country: "CN",
+		Lines:   []fcLine{{"a", 1, 5}, {"b", 6, 5}},
+		Ship:    fcAddr{City: "Beijing"},
+		ByCode:  map[string]fcLine{"x1": {"c", 1, 2}},
+	}
+	v := Struct(o)
+	v.StopOnError = false
+	v.AddValidator("qtyInStock", qtyInStock)
+	v.AddValidator("cityInCountry", cityInCountry)
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.True(v.Errors.HasField("Lines.1.Qty"))
+	is.Contains(paths, "Lines.0.Qty")
+	is.Contains(paths, "Lines.1.Qty")
+	is.Eq([]int{0, 1}, indexes)
+
+	// map of struct: key
+	paths, indexes, keys = nil, nil, nil
+	o.Lines = nil
+	v = Struct(o)
+	v.AddValidator("qtyInStock", qtyInStock)
+	v.AddValidator("cityInCountry", cityInCountry)
+	is.True(v.Validate())
+	is.Eq([]string{"ByCode.x1.Qty"}, paths)
+	is.Eq([]any{"x1"}, keys)
+
+	o.Ship.City = "Paris"
+	v = Struct(o)
+	v.AddValidator("qtyInStock", qtyInStock)
+	v.AddValidator("cityInCountry", cityInCountry)
+	is.False(v.Validate())
+	is.True(v.Errors.HasField("Ship.City"))
+}
+
+func TestFieldCtx_wildcardElem(t *testing.T) {
+	is := assert.New(t)
+
+	var paths []string
+	v := New(map[string]any{
+		"items": []any{
+			map[string]any{"qty": 1, "stock": 5},
+			map[string]any{"qty": 6, "stock": 5},
+		},
+	})
+	v.AddValidator("qtyInStock", func(fc FieldCtx) bool {
+		paths = append(paths, fc.Path())
+		idx, ok := fc.Index()
+		is.True(ok)
+		is.Eq(len(paths)-1, idx)
+		is.Eq(reflect.Map, fc.Parent().Kind())
+
+		stock, ok := fc.Sibling("stock")
+		is.True(ok)
+		return fc.Value().Int() <= stock.Int()
+	})
+	v.StringRule("items.*.qty", "qtyInStock")
+	is.False(v.Validate())
+	is.Eq([]string{"items.0.qty", "items.1.qty"}, paths)
+	is.Eq("items.*.qty", v.FieldErrors()[0].Field)
+
+	// the element without the path is skipped
+	paths = paths[:0]
+	v = New(map[string]any{
+		"items": []any{
+			map[string]any{"qty": 1},
+			map[string]any{"name": "abc"},
+			map[string]any{"qty": 2},
+		},
+	})
+	v.AddValidator("qtyPath", func(fc FieldCtx) bool {
+		paths = append(paths, fc.Path())
+		return true
+	})
+	v.StringRule("items.*.qty", "qtyPath")
+	is.True(v.Validate())
+	is.Eq([]string{"items.0.qty", "items.2.qty"}, paths)
+
+	// top-level field: parent is the root
+	v = New(map[string]any{"age": 20, "max": 18})
+	v.AddValidator("leMax", func(fc FieldCtx) bool {
+		is.Eq("age", fc.Path())
+		is.Eq(fc.Root().Pointer(), fc.Parent().Pointer())
+		_, ok := fc.Key()
+		is.True(ok)
+		max, _ := fc.Sibling("max")
+		return fc.Value().Int() <= max.Int()
+	})
+	v.StringRule("age", "leMax")
+	is.False(v.Validate())
+}
@@ -76,11 +76,13 @@ func (v *Validation) FilterRule(field string, rule string) *FilterRule {
 	rules := stringSplit(strings.Trim(rule, "|:"), "|")
 	fields := stringSplit(field, ",")
 
+	r := newFilterRule(fields)
 	if len(fields) == 0 || len(rules) == 0 {
-		panicf("no enough arguments or contains invalid argument for add filter rule")
+		// on CollectConfigErr, return the rule but not add it.
+		v.configErrorf(field, "", "no enough arguments or contains invalid argument for add filter rule")
+		return r
 	}
 
-	r := newFilterRule(fields)
 	r.AddFilters(rules...)
 	v.filterRules = append(v.filterRules, r)
 
@@ -0,0 +1,190 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/validate/v2/internal/fieldval"
+)
+
+// GeneratedValidator is implemented by the code generated by cmd/validate-gen.
+// It validates the struct fields without reflection.
+//
+// Check and CheckErr prefer it over the reflection rules, when the struct has
+// no dynamic fields, no config/translate/message/struct-level hooks and no
+// scene is given. The errors and the safe data are same as the reflection
+// rules: the error keys and messages are built from the struct tags, and the
+// safe data is read from the validated fields.
+//
+// Generate:
+//
+//	//go:generate go run github.com/gookit/validate/v2/cmd/validate-gen -type User
+type GeneratedValidator interface {
+	// ValidateGenerated validate the fields, call fail on each failed rule.
+	// should return on fail returns true.
+	ValidateGenerated(fail GenFailFunc)
+}
+
+// GenFailFunc report a failed rule of the generated validator.
+//
+//   - field the struct field path. eg: "Name"
+//   - validator the validator name in the rule. eg: "minLen"
+//   - val the failing value, args the rule arguments
+//
+// returns true on should stop the validation.
+type GenFailFunc func(field, validator string, val any, args ...any) (stop bool)
+
+// generatedOf returns the generated validator of the struct pointer, nil if
+// cannot use it.
+func generatedOf(ptr any, scene []string) (GeneratedValidator, *typeMeta) {
+	gv, ok := ptr.(GeneratedValidator)
+	if !ok || (len(scene) > 0 && scene[0] != "") || !gOpt.SkipOnEmpty {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	tm := getTypeMeta(rv.Elem().Type())
+	if tm.generatedTrans() == nil {
+		return nil, nil
+	}
+	return gv, tm
+}
+
+// generatedTrans returns the translator built from the static rule template,
+// nil if the type cannot use the generated validator.
+func (tm *typeMeta) generatedTrans() *Translator {
+	tm.genOnce.Do(func() {
+		if !tm.isStatic || tm.implConfig || tm.implTranslates || tm.implMessages || tm.hasStructLevel {
+			return
+		}
+
+		tpl := tm.staticTemplate()
+		if len(tpl.filterRules) > 0 || len(tpl.defValues) > 0 || len(tpl.configErrs) > 0 {
+			return
+		}
+
+		t := NewTranslator()
+		for field, label := range tpl.labelMap {
+			t.addLabelName(field, label)
+		}
+		t.AddFieldMap(tpl.fieldMap)
+		for key, msg := range tpl.messages {
+			t.AddMessage(key, msg)
+		}
+		tm.genTrans = t
+	})
+	return tm.genTrans
+}
+
+// genResult collect the errors of the generated validator.
+type genResult struct {
+	tm    *typeMeta
+	trans *Translator
+	// collect all errors for Check, otherwise only the first error for CheckErr
+	collect bool
+	errors  Errors
+	// the errors with details in the occurred order
+	fieldErrs fieldErrorList
+	err       error
+}
+
+func runGenerated(gv GeneratedValidator, tm *typeMeta, collect bool) *genResult {
+	gr := &genResult{tm: tm, trans: tm.genTrans, collect: collect}
+	gv.ValidateGenerated(gr.fail)
+	return gr
+}
+
+func (gr *genResult) fail(field, validator string, val any, args ...any) bool {
+	msg := gr.trans.Message(validator, field, args...)
+	if gOpt.ErrShowValue {
+		msg = fmt.Sprintf("%s (value: %v)", msg, val)
+	}
+
+	// CheckErr only returns the first error
+	if !gr.collect {
+		gr.err = errorx.Raw(msg)
+		return true
+	}
+
+	if gr.errors == nil {
+		gr.errors = make(Errors)
+	}
+	fe := FieldError{
+		Field:     field,
+		Name:      gr.errKey(field),
+		Path:      outputPathOf(gr.tm.Type, field),
+		Label:     gr.trans.LabelName(field),
+		Validator: ValidatorName(validator),
+		Alias:     validator,
+		Args:      args,
+		Value:     val,
+		Message:   msg,
+	}
+	gr.errors.Add(fe.Name, fe.Alias, fe.Message)
+	gr.fieldErrs.add(fe)
+	return gOpt.StopOnError
+}
+
+// errKey same as Validation.errKey
+func (gr *genResult) errKey(field string) string {
+	if key, ok := gr.tm.staticTemplate().errKeys[field]; ok {
+		return key
+	}
+	return gr.trans.FieldName(field)
+}
+
+func (gr *genResult) firstError() error {
+	return gr.err
+}
+
+// result build the result for Check. the safe data is cleared on failed, same
+// as the reflection rules.
+func (gr *genResult) result(ptr any) *ValidResult {
+	r := &ValidResult{Errors: gr.errors, fieldErrors: gr.fieldErrs.items}
+	if len(gr.errors) > 0 {
+		r.safeData = make(M)
+	} else {
+		r.safeData = gr.safeData(ptr)
+	}
+	return r
+}
+
+// safeData collect the values of the validated fields, same as the reflection
+// rules: the value is collected on a rule of the field is not skipped.
+func (gr *genResult) safeData(ptr any) M {
+	sd, err := FromStruct(ptr)
+	if err != nil {
+		return nil
+	}
+
+	var safe M
+	for _, r := range gr.tm.staticTemplate().rules {
+		if r.scene != "" || r.optional {
+			continue
+		}
+
+		for _, field := range r.fields {
+			var fv *fieldval.FieldValue
+			if rv, exist, _ := sd.tryGetRV(field); exist {
+				fv = fieldval.NewRV(field, rv)
+			} else {
+				fv = fieldval.New(field, nil)
+			}
+
+			// empty value AND is not required* AND skip on empty.
+			if r.skipEmpty && r.nameNotRequired && fv.IsEmpty() {
+				continue
+			}
+			if safe == nil {
+				safe = make(M)
+			}
+			safe[field] = fv.Src()
+		}
+	}
+	return safe
+}
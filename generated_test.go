@@ -0,0 +1,125 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+// genUser has a hand-written generated validator
+type genUser struct {
+	Name string `json:"name" validate:"required|minLen:3" label:"User Name"`
+	Age  int    `json:"age" validate:"min:1" message:"min:age is too small"`
+
+	calls int
+}
+
+func (u *genUser) ValidateGenerated(fail GenFailFunc) {
+	u.calls++
+	if u.Name == "" && fail("Name", "required", u.Name) {
+		return
+	}
+	if u.Age != 0 && u.Age < 1 && fail("Age", "min", u.Age, 1) {
+		return
+	}
+}
+
+// genSlUser has the struct-level hook, cannot use the generated validator
+type genSlUser struct {
+	Name string `validate:"required"`
+
+	calls int
+}
+
+func (u *genSlUser) ValidateGenerated(_ GenFailFunc) { u.calls++ }
+
+func (u *genSlUser) ValidateStruct(_ StructLevel) error { return nil }
+
+func TestCheckErr_generated(t *testing.T) {
+	is := assert.New(t)
+
+	u := &genUser{Age: -1}
+	err := CheckErr(u)
+	is.Eq(1, u.calls)
+	is.Eq("User Name is required to not be empty", err.Error())
+
+	// only the first error is returned
+	Config(func(opt *GlobalOption) {
+		opt.StopOnError = false
+	})
+	u.Name = "tom"
+	is.Eq("age is too small", CheckErr(u).Error())
+	is.Eq(2, u.calls)
+	ResetOption()
+
+	u = &genUser{Name: "tom", Age: 2}
+	is.NoErr(CheckErr(u))
+	is.Eq(1, u.calls)
+
+	// fallback to the reflection rules
+	u = &genUser{Name: "to"}
+	is.Err(CheckErr(u, "create"))
+	is.Eq(0, u.calls)
+
+	su := &genSlUser{}
+	is.Err(CheckErr(su))
+	is.Eq(0, su.calls)
+}
+
+func TestCheck_generated(t *testing.T) {
+	is := assert.New(t)
+
+	// same errors and safe data as Struct().ValidateR()
+	u := &genUser{Name: "tom", Age: 2}
+	r := Check(u)
+	is.Eq(1, u.calls)
+	is.True(r.IsOK())
+
+	ref := Struct(u).ValidateR()
+	is.Eq(ref.SafeData(), r.SafeData())
+	is.Eq(M{"Name": "tom", "Age": 2}, r.SafeData())
+
+	var got, want genUser
+	is.NoErr(r.BindSafeData(&got))
+	is.NoErr(ref.BindSafeData(&want))
+	is.Eq(want, got)
+	is.Eq("tom", got.Name)
+	is.Eq(2, got.Age)
+
+	// the empty field is skipped
+	u = &genUser{Name: "tom"}
+	is.Eq(M{"Name": "tom"}, Check(u).SafeData())
+	is.Eq(Struct(u).ValidateR().SafeData(), Check(u).SafeData())
+
+	u = &genUser{Age: -1}
+	r = Check(u)
+	is.Eq(1, u.calls)
+	is.Eq("User Name is required to not be empty", r.Errors.FieldOne("name"))
+	is.Eq(r.One(), CheckErr(u).Error())
+	is.Empty(r.SafeData())
+
+	// collect all errors
+	Config(func(opt *GlobalOption) {
+		opt.StopOnError = false
+	})
+	defer ResetOption()
+
+	r = Check(u)
+	ref = Struct(u).ValidateR()
+	is.Len(r.FieldErrors(), 2)
+	is.Eq(ref.Errors, r.Errors)
+	for i, fe := range ref.FieldErrors() {
+		got := r.FieldErrors()[i]
+		is.Eq(fe.Path, got.Path)
+		is.Eq(fe.Label, got.Label)
+		is.Eq(fe.Validator, got.Validator)
+		is.Eq(fe.Value, got.Value)
+	}
+	is.Eq("age", r.FieldErrors()[1].Path)
+	is.Eq(ref.Err(), r.Err())
+
+	// fallback to the reflection rules
+	u = &genUser{Name: "to"}
+	is.True(Check(u, "create").Fail())
+	is.Eq(0, u.calls)
+}
@@ -0,0 +1,565 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/goutil/mathutil"
+)
+
+// JSONSchemaDraft the JSON Schema dialect of the exported schema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a JSON Schema (draft 2020-12) document or sub schema, only
+// contains the keywords can be mapped from the validators.
+type JSONSchema struct {
+	Schema string `json:"$schema,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Type   string `json:"type,omitempty"`
+	// Types the multi types. eg: "type": ["string", "null"]
+	Types   []string `json:"-"`
+	Format  string   `json:"format,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Default any      `json:"default,omitempty"`
+	Enum    []any    `json:"enum,omitempty"`
+	Const   any      `json:"const,omitempty"`
+	// Not the value must not be valid against the schema. eg: notIn
+	Not *JSONSchema `json:"not,omitempty"`
+
+	// sub schemas combination
+	AllOf []*JSONSchema `json:"allOf,omitempty"`
+	AnyOf []*JSONSchema `json:"anyOf,omitempty"`
+	OneOf []*JSONSchema `json:"oneOf,omitempty"`
+
+	// numeric bounds
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	// string and array length
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+	MinItems  *int `json:"minItems,omitempty"`
+	MaxItems  *int `json:"maxItems,omitempty"`
+
+	// object and array
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *JSONSchema            `json:"propertyNames,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+
+	// Unmapped the rules can not be mapped to the JSON Schema keywords, as the
+	// rule string. eg: "eqField:Name", "dive|keys|isAlpha"
+	Unmapped []string `json:"x-unmapped-rules,omitempty"`
+
+	// the unsupported keywords on decode. eg: "$ref", "multipleOf"
+	unknown []string
+}
+
+type jsonSchemaAlias JSONSchema
+
+// MarshalJSON the schema, the Types is encoded as the "type" array.
+func (s JSONSchema) MarshalJSON() ([]byte, error) {
+	if len(s.Types) == 0 {
+		return json.Marshal((*jsonSchemaAlias)(&s))
+	}
+
+	return json.Marshal(struct {
+		*jsonSchemaAlias
+		Type []string `json:"type"`
+	}{(*jsonSchemaAlias)(&s), s.Types})
+}
+
+// UnmarshalJSON decode the schema, support the boolean schema and the "type"
+// array. The unsupported keywords are recorded, see RuleSetFromJSONSchema
+func (s *JSONSchema) UnmarshalJSON(bs []byte) error {
+	switch string(bytes.TrimSpace(bs)) {
+	case "true":
+		*s = JSONSchema{}
+		return nil
+	case "false": // same as {"not": {}}
+		*s = JSONSchema{Not: &JSONSchema{}}
+		return nil
+	}
+
+	var raw struct {
+		*jsonSchemaAlias
+		Type json.RawMessage `json:"type,omitempty"`
+	}
+	raw.jsonSchemaAlias = (*jsonSchemaAlias)(s)
+	if err := json.Unmarshal(bs, &raw); err != nil {
+		return err
+	}
+
+	if len(raw.Type) > 0 {
+		if raw.Type[0] != '[' {
+			if err := json.Unmarshal(raw.Type, &s.Type); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(raw.Type, &s.Types); err != nil {
+			return err
+		}
+		if len(s.Types) == 1 {
+			s.Type, s.Types = s.Types[0], nil
+		}
+	}
+
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &keys); err != nil {
+		return err
+	}
+	for key := range keys {
+		if !schemaKeywords[key] && !strings.HasPrefix(key, "x-") {
+			s.unknown = append(s.unknown, key)
+		}
+	}
+	sort.Strings(s.unknown)
+	return nil
+}
+
+// schemaKeywords the known keywords of the JSONSchema and the annotations.
+var schemaKeywords = func() map[string]bool {
+	keys := map[string]bool{
+		"type": true, "$id": true, "$comment": true, "description": true, "examples": true,
+		"deprecated": true, "readOnly": true, "writeOnly": true,
+	}
+
+	rt := reflect.TypeOf(JSONSchema{})
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}()
+
+// UnmappedRules returns the unmapped rules of the schema and all sub schemas,
+// the key is the property path. eg: {"items.*.sku": ["eqField:Name"]}
+func (s *JSONSchema) UnmappedRules() map[string][]string {
+	ret := make(map[string][]string)
+	s.collectUnmapped("", ret)
+	return ret
+}
+
+func (s *JSONSchema) collectUnmapped(path string, ret map[string][]string) {
+	if len(s.Unmapped) > 0 {
+		ret[path] = s.Unmapped
+	}
+
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+	for name, ps := range s.Properties {
+		ps.collectUnmapped(join(name), ret)
+	}
+	if s.Items != nil {
+		s.Items.collectUnmapped(join("*"), ret)
+	}
+	if s.AdditionalProperties != nil {
+		s.AdditionalProperties.collectUnmapped(join("*"), ret)
+	}
+}
+
+// ExportJSONSchema export the struct rules as a JSON Schema (draft 2020-12)
+// document. The s can be a struct value, pointer or reflect.Type.
+//
+// The properties are named by the JSON field names, the label is used as the
+// title. The validators are mapped to the keywords:
+//
+//   - required: required
+//   - minLen, maxLen, len: minLength, maxLength or minItems, maxItems for the array
+//   - min, max, between, gt, lt: numeric bounds
+//   - enum/in, notIn: enum, not.enum
+//   - regexp: pattern
+//   - email, isURL, isUUID, isDate, isIPv4, isIPv6: format
+//
+// The rules after "dive" are mapped to the items or additionalProperties, the
+// map key rules are mapped to the propertyNames. The validators can not be
+// mapped are reported in the "x-unmapped-rules", see JSONSchema.UnmappedRules
+//
+// Usage:
+//
+//	s, err := validate.ExportJSONSchema(User{})
+//	bs, err := json.MarshalIndent(s, "", "  ")
+func ExportJSONSchema(s any) (*JSONSchema, error) {
+	d, err := DescribeStruct(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, _ := structTypeOf(s)
+	x := &schemaExporter{desc: d, ancestors: map[reflect.Type]bool{rt: true}}
+
+	root := x.structSchema(rt, "")
+	root.Schema = JSONSchemaDraft
+	root.Title = rt.Name()
+	return root, nil
+}
+
+type schemaExporter struct {
+	desc *Descriptor
+	// the struct types on the walk path, stop on the recursive type.
+	ancestors map[reflect.Type]bool
+}
+
+func (x *schemaExporter) structSchema(rt reflect.Type, prefix string) *JSONSchema {
+	s := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+	x.addFields(s, rt, prefix)
+	return s
+}
+
+// addFields add the struct fields to the object schema s. the embedded struct
+// without JSON name is inlined, same as encoding/json.
+func (x *schemaExporter) addFields(s *JSONSchema, rt reflect.Type, prefix string) {
+	for _, fm := range getTypeMeta(rt).Fields {
+		// only the direct fields, the sub struct is walked by typeSchema.
+		if len(fm.Index) != 1 {
+			continue
+		}
+
+		sf := rt.Field(fm.Index[0])
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		path := fm.Name
+		if prefix != "" {
+			path = prefix + "." + fm.Name
+		}
+
+		ft := removeTypePtr(sf.Type)
+		if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+			x.addFields(s, ft, path)
+			continue
+		}
+		if name == "" {
+			name = fm.Name
+		}
+
+		ps := x.typeSchema(sf.Type, path)
+		if f := x.desc.Field(path); f != nil && x.applyField(ps, f) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = ps
+	}
+}
+
+// typeSchema build the schema by the Go type. path is the rule field path.
+func (x *schemaExporter) typeSchema(t reflect.Type, path string) *JSONSchema {
+	t = removeTypePtr(t)
+	if t == timeType {
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Struct:
+		if x.ancestors[t] {
+			return &JSONSchema{Type: "object"}
+		}
+
+		x.ancestors[t] = true
+		s := x.structSchema(t, path)
+		delete(x.ancestors, t)
+		return s
+	case reflect.Slice, reflect.Array:
+		// []byte is encoded as the base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string"}
+		}
+
+		s := &JSONSchema{Type: "array", Items: x.typeSchema(t.Elem(), path+".*")}
+		if t.Kind() == reflect.Array {
+			s.MinItems, s.MaxItems = intPtr(t.Len()), intPtr(t.Len())
+		}
+		return s
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: x.typeSchema(t.Elem(), path+".*")}
+	}
+	return &JSONSchema{}
+}
+
+// applyField apply the field label, default value and rules to the schema s.
+// returns true if the field is required.
+func (x *schemaExporter) applyField(s *JSONSchema, f *FieldDesc) (required bool) {
+	if f.Label != "" {
+		s.Title = f.Label
+	}
+	if f.Default != nil {
+		s.Default = schemaValue(s.Type, f.Default)
+	}
+
+	for _, rd := range f.Rules {
+		// the scene rules are not applied by default
+		if rd.Scene == "" {
+			if rd.Dive == 0 && rd.Name == RuleRequired {
+				required = true
+				continue
+			}
+			if applySchemaRule(elemSchemaOf(s, rd), rd) {
+				continue
+			}
+		}
+		s.Unmapped = append(s.Unmapped, schemaRuleText(rd))
+	}
+	return
+}
+
+// elemSchemaOf get the schema for the dive depth of the rule.
+func elemSchemaOf(s *JSONSchema, rd *RuleDesc) *JSONSchema {
+	for i := 1; i <= rd.Dive; i++ {
+		switch {
+		case i == rd.Dive && rd.Key:
+			if s.PropertyNames == nil {
+				s.PropertyNames = &JSONSchema{Type: "string"}
+			}
+			s = s.PropertyNames
+		case s.Type == "object":
+			if s.AdditionalProperties == nil {
+				s.AdditionalProperties = &JSONSchema{}
+			}
+			s = s.AdditionalProperties
+		default:
+			if s.Items == nil {
+				s.Items = &JSONSchema{}
+			}
+			s = s.Items
+		}
+	}
+	return s
+}
+
+// the validators mapped to the format
+var schemaFormats = map[string]string{
+	"isEmail":   "email",
+	"isURL":     "uri",
+	"isFullURL": "uri",
+	"isUUID":    "uuid",
+	"isUUID3":   "uuid",
+	"isUUID4":   "uuid",
+	"isUUID5":   "uuid",
+	"isDate":    "date",
+	"isIPv4":    "ipv4",
+	"isIPv6":    "ipv6",
+}
+
+// the type validators mapped to the type
+var schemaTypes = map[string]string{
+	"isInt":     "integer",
+	"isUint":    "integer",
+	"isFloat":   "number",
+	"isString":  "string",
+	"isBool":    "boolean",
+	"isArray":   "array",
+	"isSlice":   "array",
+	"isInts":    "array",
+	"isStrings": "array",
+	"isMap":     "object",
+}
+
+// applySchemaRule map the rule to the schema keywords, returns false if the
+// rule can not be mapped.
+func applySchemaRule(s *JSONSchema, rd *RuleDesc) bool {
+	name := rd.Name
+	switch name {
+	case RuleOptional, RuleSafe, RuleSafe1:
+		return true
+	case RuleRequired: // the element or map key is required
+		if s.Type == "string" {
+			s.MinLength = intPtr(1)
+			return true
+		}
+		return false
+	case "minLength", "maxLength", "length":
+		n, err := ruleArgInt(rd, 0)
+		if err != nil {
+			return false
+		}
+
+		minPtr, maxPtr := &s.MinLength, &s.MaxLength
+		switch s.Type {
+		case "array":
+			minPtr, maxPtr = &s.MinItems, &s.MaxItems
+		case "string":
+		default:
+			return false
+		}
+		if name != "maxLength" {
+			*minPtr = intPtr(n)
+		}
+		if name != "minLength" {
+			*maxPtr = intPtr(n)
+		}
+		return true
+	case "min", "max", "gt", "lt":
+		f, err := ruleArgFloat(rd, 0)
+		if err != nil {
+			return false
+		}
+
+		switch name {
+		case "min":
+			s.Minimum = &f
+		case "max":
+			s.Maximum = &f
+		case "gt":
+			s.ExclusiveMinimum = &f
+		default:
+			s.ExclusiveMaximum = &f
+		}
+		return true
+	case "between":
+		minVal, err1 := ruleArgFloat(rd, 0)
+		maxVal, err2 := ruleArgFloat(rd, 1)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		s.Minimum, s.Maximum = &minVal, &maxVal
+		return true
+	case "enum", "notIn":
+		if len(rd.Args) == 0 {
+			return false
+		}
+
+		enum := schemaEnum(s.Type, rd.Args)
+		if name == "enum" {
+			s.Enum = enum
+		} else {
+			s.Not = &JSONSchema{Enum: enum}
+		}
+		return true
+	case RuleRegexp:
+		if len(rd.Args) != 1 {
+			return false
+		}
+		s.Pattern = fmt.Sprint(rd.Args[0])
+		return true
+	}
+
+	if format, ok := schemaFormats[name]; ok {
+		s.Format = format
+		return true
+	}
+
+	if typ, ok := schemaTypes[name]; ok {
+		if s.Type == "" {
+			s.Type = typ
+		}
+		return s.Type == typ || typ == "number" && s.Type == "integer"
+	}
+	return false
+}
+
+func ruleArgInt(rd *RuleDesc, i int) (int, error) {
+	if i >= len(rd.Args) {
+		return 0, fmt.Errorf("missing the arg #%d", i)
+	}
+	return mathutil.Int(rd.Args[i])
+}
+
+func ruleArgFloat(rd *RuleDesc, i int) (float64, error) {
+	if i >= len(rd.Args) {
+		return 0, fmt.Errorf("missing the arg #%d", i)
+	}
+	return mathutil.Float(rd.Args[i])
+}
+
+// schemaEnum build the enum values by the rule args. eg: [["a", "b"]]
+func schemaEnum(typ string, args []any) []any {
+	var enum []any
+	for _, arg := range args {
+		rv := reflect.ValueOf(arg)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			enum = append(enum, schemaValue(typ, arg))
+			continue
+		}
+		for i := 0; i < rv.Len(); i++ {
+			enum = append(enum, schemaValue(typ, rv.Index(i).Interface()))
+		}
+	}
+	return enum
+}
+
+// schemaValue convert the string value by the schema type. eg: "1" -> 1
+func schemaValue(typ string, val any) any {
+	str, ok := val.(string)
+	if !ok {
+		return val
+	}
+
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b
+		}
+	}
+	return val
+}
+
+// schemaRuleText format the rule as the rule string. eg: "dive|keys|alpha"
+func schemaRuleText(rd *RuleDesc) string {
+	var sb strings.Builder
+	for i := 0; i < rd.Dive; i++ {
+		sb.WriteString(RuleDive + "|")
+	}
+	if rd.Key {
+		sb.WriteString(RuleKeys + "|")
+	}
+
+	if rd.Name == RuleExprName {
+		sb.WriteString("(" + fmt.Sprint(rd.Args...) + ")")
+	} else {
+		sb.WriteString(rd.Validator)
+		for i, arg := range rd.Args {
+			if i == 0 {
+				sb.WriteByte(':')
+			} else {
+				sb.WriteByte(',')
+			}
+			if ss, ok := arg.([]string); ok {
+				sb.WriteString(strings.Join(ss, ","))
+			} else {
+				sb.WriteString(fmt.Sprint(arg))
+			}
+		}
+	}
+
+	if rd.Scene != "" {
+		sb.WriteString(" (scene: " + rd.Scene + ")")
+	}
+	return sb.String()
+}
+
+func intPtr(n int) *int { return &n }
@@ -0,0 +1,592 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LoadJSONSchema decode the JSON Schema document from r, and compile it to a
+// RuleSet. see RuleSetFromJSONSchema
+//
+// Usage:
+//
+//	rs, err := validate.LoadJSONSchema(file)
+//	res := rs.Validate(body) // body is the JSON bytes
+func LoadJSONSchema(r io.Reader) (*RuleSet, error) {
+	s := &JSONSchema{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("%w: decode the JSON Schema: %w", ErrInvalidData, err)
+	}
+	return RuleSetFromJSONSchema(s)
+}
+
+// RuleSetFromJSONSchema compile the JSON Schema of an object to a RuleSet, use
+// for validate the map data. eg: the JSON request body.
+//
+// The properties are mapped to the field paths, the array item properties use
+// the wildcard. eg: "user.name", "items.*.sku". The keywords are mapped to the
+// validators, so the error messages are translated same as the other rules:
+//
+//   - type: string, int, float, bool, map, slice. check by the JSON types
+//   - required: requiredKey, the property must exist. the "", 0 and [] are valid
+//   - enum, const: enum; not.enum: notIn
+//   - pattern: regexp
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum: min, max, gt, lt
+//   - minLength, maxLength: stringLength
+//   - minItems, maxItems: minLen, maxLen
+//   - format: email, uri, uuid, date, ipv4, ipv6, hostname. others are ignored
+//   - items, additionalProperties, propertyNames: dive
+//   - allOf: merged; anyOf, oneOf: the rule expression. see RuleExprName
+//
+// Differences from the JSON Schema:
+//
+//   - the null value is same as not exists.
+//   - the keywords except the type are skipped on the value is empty. see SkipOnEmpty
+//   - the oneOf branches must have the different types, so at most one can match.
+//   - the root additionalProperties and propertyNames are not checked.
+//
+// The unsupported keywords are returned as the ConfigErrors. eg: "$ref"
+func RuleSetFromJSONSchema(s *JSONSchema) (*RuleSet, error) {
+	return CompileRuleSet(func(v *Validation) {
+		v.AddValidator(ruleRequiredKey, jsonRequiredKey)
+
+		im := &schemaImporter{v: v}
+		im.checkKeywords(s, "")
+		if s.Type != "" && s.Type != "object" || len(s.Types) > 0 {
+			v.configErrorf("", "type", "the root JSON Schema type must be 'object'")
+			return
+		}
+		if len(s.AnyOf) > 0 || len(s.OneOf) > 0 || s.Enum != nil || s.Const != nil || s.Not != nil {
+			v.configErrorf("", "", "the root JSON Schema only supports the object keywords")
+		}
+
+		for _, sub := range s.AllOf {
+			im.checkKeywords(sub, "")
+		}
+		im.structure(s, "", true)
+	})
+}
+
+// jsonSchemaValidators the type and enum validators with the JSON types
+// semantics, keyed by the validator name. eg: 1.0 is an integer, "1" is not
+// a number. They are set as the check func of the schema rules, so the rules
+// keep the validator names and messages, the registered validators are not changed.
+var jsonSchemaValidators = map[string]*funcMeta{
+	"isString": newSchemaFuncMeta("jsonIsString", jsonIsString),
+	"isInt":    newSchemaFuncMeta("jsonIsInt", jsonIsInt),
+	"isFloat":  newSchemaFuncMeta("jsonIsNumber", jsonIsNumber),
+	"isBool":   newSchemaFuncMeta("jsonIsBool", jsonIsBool),
+	"isMap":    newSchemaFuncMeta("jsonIsMap", jsonIsMap),
+	"isSlice":  newSchemaFuncMeta("jsonIsSlice", jsonIsSlice),
+	"enum":     newSchemaFuncMeta("jsonEnum", jsonEnum),
+	"notIn":    newSchemaFuncMeta("jsonNotIn", func(val, enum any) bool { return !jsonEnum(val, enum) }),
+}
+
+func newSchemaFuncMeta(name string, fn any) *funcMeta {
+	return newFuncMeta(name, false, reflect.ValueOf(fn))
+}
+
+// ruleRequiredKey the validator of the JSON Schema required, only check the
+// property exists. it starts with "required", so it is checked on the empty value.
+const ruleRequiredKey = "requiredKey"
+
+// jsonRequiredKey check the value is not null. same as "required", it is skipped
+// on the optional parent is empty. the parent objects are all optional, so check
+// each of them. eg: "a.b.c" is skipped on "a" or "a.b" is empty
+func jsonRequiredKey(fc FieldCtx) bool {
+	c := fc.(*fieldCtx)
+	if !isJSONNull(c.fv.Src()) {
+		return true
+	}
+
+	for name := range c.v.optionals {
+		if strings.HasPrefix(c.field, name+".") {
+			if val, exist, zero := c.v.tryGet(name); !exist || zero || IsEmpty(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// the rules of the JSON Schema types. the "null" has no rule.
+var schemaTypeRules = map[string]string{
+	"string":  "string",
+	"integer": "int",
+	"number":  "float",
+	"boolean": "bool",
+	"object":  "map",
+	"array":   "slice",
+}
+
+// the rules of the JSON Schema formats
+var schemaFormatRules = map[string]string{
+	"email":    "email",
+	"uri":      "fullURL",
+	"uuid":     "uuid",
+	"date":     "date",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "dnsName",
+}
+
+// the JSON type validators, the null value is same as not exists, skip it.
+
+func jsonIsString(val any) bool {
+	if _, ok := val.(json.Number); ok {
+		return false
+	}
+	return isJSONNull(val) || reflect.ValueOf(val).Kind() == reflect.String
+}
+
+func jsonIsInt(val any) bool {
+	f, ok := jsonNumber(val)
+	return isJSONNull(val) || ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+}
+
+func jsonIsNumber(val any) bool {
+	_, ok := jsonNumber(val)
+	return isJSONNull(val) || ok
+}
+
+func jsonIsBool(val any) bool {
+	_, ok := val.(bool)
+	return isJSONNull(val) || ok
+}
+
+func jsonIsMap(val any) bool {
+	return isJSONNull(val) || reflect.ValueOf(val).Kind() == reflect.Map
+}
+
+func jsonIsSlice(val any) bool {
+	if isJSONNull(val) {
+		return true
+	}
+
+	kind := reflect.ValueOf(val).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// isJSONNull check the value is nil, the validator gets NilObject for nil.
+func isJSONNull(val any) bool {
+	return val == nil || IsNilObj(val)
+}
+
+// jsonEnum check the val is equal to one of the enum values, the numbers are
+// compared by the value. eg: 1 == 1.0
+func jsonEnum(val, enum any) bool {
+	list, ok := enum.([]any)
+	if !ok {
+		return Enum(val, enum)
+	}
+
+	num, isNum := jsonNumber(val)
+	for _, item := range list {
+		if f, ok := jsonNumber(item); ok && isNum {
+			if f == num {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(val, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonNumber get the float value of the number val.
+func jsonNumber(val any) (float64, bool) {
+	if n, ok := val.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+type schemaImporter struct {
+	v *Validation
+}
+
+// checkKeywords report the unsupported keywords of the decoded schema.
+func (im *schemaImporter) checkKeywords(s *JSONSchema, path string) {
+	for _, key := range s.unknown {
+		im.v.configErrorf(path, key, "unsupported JSON Schema keyword '%s'", key)
+	}
+}
+
+// object add the rules of the object properties.
+func (im *schemaImporter) object(s *JSONSchema, prefix string) {
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := joinSchemaPath(prefix, name)
+		if strings.Contains(name, ".") {
+			im.v.configErrorf(path, "properties", "the property name '%s' can not contain '.'", name)
+			continue
+		}
+
+		if required[name] {
+			im.v.AddRule(path, ruleRequiredKey)
+			delete(required, name)
+		}
+		im.field(s.Properties[name], path)
+	}
+
+	// the required properties without schema
+	for _, name := range s.Required {
+		if required[name] {
+			im.v.AddRule(joinSchemaPath(prefix, name), ruleRequiredKey)
+			delete(required, name)
+		}
+	}
+}
+
+// field add the label and rules of the property schema.
+func (im *schemaImporter) field(s *JSONSchema, path string) {
+	if s.Title != "" {
+		im.v.AddTranslates(map[string]string{path: s.Title})
+	}
+
+	for _, r := range im.valueRules(s, path) {
+		nr := im.v.addOneRule(path, r.validator, r.realName, r.arguments)
+		nr.checkFuncMeta = r.checkFuncMeta
+		// the JSON type is checked on the empty value too. eg: "" is not an integer
+		if r.checkFuncMeta != nil && r.realName != "enum" && r.realName != "notIn" {
+			nr.skipEmpty = false
+		}
+	}
+	im.structure(s, path, true)
+}
+
+// structure add the rules of the sub properties, array items and map values.
+// the items are skipped on withItems is false, they are checked by the parent dive.
+func (im *schemaImporter) structure(s *JSONSchema, path string, withItems bool) {
+	for _, sub := range s.AllOf {
+		im.structure(sub, path, withItems)
+	}
+
+	if len(s.Properties) > 0 || len(s.Required) > 0 {
+		// skip the sub properties on the object is not exists.
+		if path != "" {
+			im.v.ensureOptionals() // lazy
+			im.v.optionals[path] = 0
+		}
+		im.object(s, path)
+	}
+
+	if s.Items != nil && withItems {
+		elemPath := path + ".*"
+		if d := im.diveOf(s.Items, elemPath); d != nil {
+			im.v.addOneRule(path, RuleDive, RuleDive, []any{d})
+		}
+
+		if hasSchemaStructure(s.Items) {
+			im.v.ensureOptionals() // lazy
+			im.v.optionals[path] = 0
+			im.structure(s.Items, elemPath, false)
+		}
+		if s.Items.Items != nil && hasSchemaStructure(s.Items.Items) {
+			im.v.configErrorf(elemPath, "items", "the properties of the nested array items are not supported")
+		}
+	}
+
+	if path != "" && (s.AdditionalProperties != nil || s.PropertyNames != nil) {
+		im.mapDive(s, path)
+	}
+}
+
+// diveOf build the dive rules of the array items, the nested array items
+// are in the next level.
+func (im *schemaImporter) diveOf(s *JSONSchema, path string) *diveRules {
+	d := &diveRules{elems: im.valueRules(s, path)}
+	if s.Items != nil {
+		d.next = im.diveOf(s.Items, path+".*")
+	}
+
+	if len(d.elems) == 0 && d.next == nil {
+		return nil
+	}
+	return d
+}
+
+// mapDive add the dive rule of the map keys and values.
+func (im *schemaImporter) mapDive(s *JSONSchema, path string) {
+	d := &diveRules{}
+	ap := s.AdditionalProperties
+	switch {
+	case ap == nil || isEmptySchema(ap):
+	case isFalseSchema(ap): // only the properties are allowed
+		names := make([]any, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return names[i].(string) < names[j].(string) })
+		d.keys = append(d.keys, newSchemaRule("enum", names))
+	case len(s.Properties) > 0:
+		im.v.configErrorf(path, "additionalProperties", "the 'additionalProperties' schema with 'properties' is not supported")
+	default:
+		if sub := im.diveOf(ap, path+".*"); sub != nil {
+			d.elems, d.next = sub.elems, sub.next
+		}
+		if hasSchemaStructure(ap) {
+			im.v.ensureOptionals() // lazy
+			im.v.optionals[path] = 0
+			im.structure(ap, path+".*", false)
+		}
+	}
+
+	if s.PropertyNames != nil {
+		d.keys = append(d.keys, im.valueRules(s.PropertyNames, path+".*")...)
+	}
+	if len(d.keys) > 0 || len(d.elems) > 0 || d.next != nil {
+		im.v.addOneRule(path, RuleDive, RuleDive, []any{d})
+	}
+}
+
+// valueRules build the rules of the value keywords, the allOf rules are merged.
+func (im *schemaImporter) valueRules(s *JSONSchema, path string) (rules []*Rule) {
+	im.checkKeywords(s, path)
+	add := func(validator string, args ...any) {
+		rules = append(rules, newSchemaRule(validator, args...))
+	}
+
+	types := s.Types
+	if s.Type != "" {
+		types = []string{s.Type}
+	}
+
+	var typeRules []*Rule
+	for _, typ := range types {
+		if name, ok := schemaTypeRules[typ]; ok {
+			typeRules = append(typeRules, newSchemaRule(name))
+		} else if typ != "null" {
+			im.v.configErrorf(path, "type", "unknown JSON Schema type '%s'", typ)
+		}
+	}
+	if e := schemaExprOf(exprOr, typeRules); e != nil {
+		rules = append(rules, exprRuleOf(e))
+	}
+
+	if s.Enum != nil {
+		add("enum", s.Enum)
+	}
+	if s.Const != nil {
+		add("enum", []any{s.Const})
+	}
+	if s.Not != nil {
+		onlyEnum := *s.Not
+		onlyEnum.Enum = nil
+		if s.Not.Enum != nil && isEmptySchema(&onlyEnum) {
+			add("notIn", s.Not.Enum)
+		} else {
+			im.v.configErrorf(path, "not", "only the 'not' with 'enum' is supported")
+		}
+	}
+
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			im.v.configErrorf(path, "pattern", "invalid pattern: %s", err.Error())
+		} else {
+			add(RuleRegexp, s.Pattern)
+		}
+	}
+
+	if s.Minimum != nil {
+		add("min", *s.Minimum)
+	}
+	if s.Maximum != nil {
+		add("max", *s.Maximum)
+	}
+	if s.ExclusiveMinimum != nil {
+		add("gt", *s.ExclusiveMinimum)
+	}
+	if s.ExclusiveMaximum != nil {
+		add("lt", *s.ExclusiveMaximum)
+	}
+
+	switch {
+	case s.MinLength != nil && s.MaxLength != nil:
+		add("stringLength", *s.MinLength, *s.MaxLength)
+	case s.MinLength != nil:
+		add("stringLength", *s.MinLength)
+	case s.MaxLength != nil:
+		add("stringLength", 0, *s.MaxLength)
+	}
+	if s.MinItems != nil {
+		add("minLen", *s.MinItems)
+	}
+	if s.MaxItems != nil {
+		add("maxLen", *s.MaxItems)
+	}
+
+	if name, ok := schemaFormatRules[s.Format]; ok {
+		add(name)
+	}
+
+	for _, sub := range s.AllOf {
+		rules = append(rules, im.valueRules(sub, path)...)
+	}
+	if r := im.combineRule(s.AnyOf, path, "anyOf"); r != nil {
+		rules = append(rules, r)
+	}
+
+	if len(s.OneOf) > 0 && !distinctSchemaTypes(s.OneOf) {
+		im.v.configErrorf(path, "oneOf", "the 'oneOf' is only supported with the sub schemas of different types")
+	} else if r := im.combineRule(s.OneOf, path, "oneOf"); r != nil {
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// combineRule build the "or" expression rule of the sub schemas. returns nil
+// if any sub schema has no rule, it always passes.
+func (im *schemaImporter) combineRule(subs []*JSONSchema, path, key string) *Rule {
+	or := &ruleExpr{kind: exprOr}
+	for _, sub := range subs {
+		if hasSchemaStructure(sub) {
+			im.v.configErrorf(path, key, "the '%s' sub schema with properties, required or items is not supported", key)
+			return nil
+		}
+
+		e := schemaExprOf(exprAnd, im.valueRules(sub, path))
+		if e == nil {
+			return nil
+		}
+		or.subs = append(or.subs, e)
+	}
+
+	if len(or.subs) == 0 {
+		return nil
+	}
+	if len(or.subs) == 1 {
+		return exprRuleOf(or.subs[0])
+	}
+	return exprRuleOf(or)
+}
+
+func newSchemaRule(validator string, args ...any) *Rule {
+	realName := ValidatorName(validator)
+	return &Rule{
+		validator:       validator,
+		realName:        realName,
+		arguments:       args,
+		nameNotRequired: true,
+		checkFuncMeta:   jsonSchemaValidators[realName],
+	}
+}
+
+// schemaExprOf join the rules by the kind, nil if the rules is empty.
+func schemaExprOf(kind uint8, rules []*Rule) *ruleExpr {
+	nodes := make([]*ruleExpr, len(rules))
+	for i, r := range rules {
+		if r.realName == RuleExprName {
+			nodes[i] = r.arguments[0].(*ruleExpr)
+		} else {
+			nodes[i] = &ruleExpr{kind: exprLeaf, rule: r, text: inlineRuleString(r)}
+		}
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return nodes[0]
+	}
+	return &ruleExpr{kind: kind, subs: nodes}
+}
+
+// exprRuleOf returns the leaf rule, or the rule of the expression.
+func exprRuleOf(e *ruleExpr) *Rule {
+	if e.kind == exprLeaf {
+		return e.rule
+	}
+
+	return &Rule{
+		validator:       RuleExprName,
+		realName:        RuleExprName,
+		arguments:       []any{e},
+		argsReady:       true,
+		nameNotRequired: true,
+	}
+}
+
+func joinSchemaPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// hasSchemaStructure check the schema has the keywords of the sub values.
+func hasSchemaStructure(s *JSONSchema) bool {
+	if len(s.Properties) > 0 || len(s.Required) > 0 || s.Items != nil ||
+		s.AdditionalProperties != nil || s.PropertyNames != nil {
+		return true
+	}
+
+	for _, sub := range s.AllOf {
+		if hasSchemaStructure(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// distinctSchemaTypes check each schema has one type and the types are different.
+// the "integer" is a "number".
+func distinctSchemaTypes(subs []*JSONSchema) bool {
+	seen := make(map[string]bool, len(subs))
+	for _, sub := range subs {
+		typ := sub.Type
+		if typ == "integer" {
+			typ = "number"
+		}
+		if typ == "" || seen[typ] {
+			return false
+		}
+		seen[typ] = true
+	}
+	return true
+}
+
+// isEmptySchema check the schema is {} or true, it accepts any value.
+func isEmptySchema(s *JSONSchema) bool {
+	return reflect.DeepEqual(*s, JSONSchema{})
+}
+
+// isFalseSchema check the schema is false, it rejects any value.
+func isFalseSchema(s *JSONSchema) bool {
+	if s.Not == nil || !isEmptySchema(s.Not) {
+		return false
+	}
+
+	cp := *s
+	cp.Not = nil
+	return isEmptySchema(&cp)
+}
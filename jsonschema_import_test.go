@@ -0,0 +1,217 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+var testOrderSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Order",
+	"type": "object",
+	"required": ["id", "user", "items"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"code": {"type": "string", "pattern": "^(A|B)-[0-9]{3}$"},
+		"status": {"enum": ["new", "paid", 3]},
+		"email": {"type": "string", "format": "email", "x-order": 1},
+		"user": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string", "title": "User name", "minLength": 2, "maxLength": 8},
+				"age": {"type": ["integer", "null"], "exclusiveMinimum": 0, "maximum": 150}
+			}
+		},
+		"contact": {
+			"type": "object",
+			"required": ["phone"],
+			"properties": {"phone": {"type": "string"}}
+		},
+		"tags": {
+			"type": "array",
+			"maxItems": 3,
+			"items": {"type": "string", "not": {"enum": ["admin"]}}
+		},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"required": ["sku"],
+				"properties": {
+					"sku": {"type": "string"},
+					"qty": {"type": "integer", "minimum": 1}
+				}
+			}
+		},
+		"matrix": {"type": "array", "items": {"type": "array", "items": {"type": "number"}}},
+		"labels": {
+			"type": "object",
+			"propertyNames": {"pattern": "^[a-z]+$"},
+			"additionalProperties": {"type": "string"}
+		},
+		"price": {"anyOf": [{"type": "number", "minimum": 0}, {"type": "string", "pattern": "^free$"}]},
+		"ref": {"oneOf": [{"type": "integer"}, {"type": "string", "format": "uuid"}]},
+		"nick": {"allOf": [{"type": "string"}, {"minLength": 3}]},
+		"kind": {"const": "order"},
+		"active": {"type": "boolean"}
+	}
+}`
+
+func TestLoadJSONSchema(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := LoadJSONSchema(strings.NewReader(testOrderSchema))
+	is.NoErr(err)
+
+	ok := `{"id": 1, "code": "A-123", "status": 3, "email": "tom@example.com",
+		"user": {"name": "tom", "age": 20}, "tags": ["go"],
+		"items": [{"sku": "S1", "qty": 2}, {"sku": "S2"}],
+		"matrix": [[1, 2.5], [3]], "labels": {"en": "hi"},
+		"price": "free", "ref": 12, "nick": "tommy", "kind": "order", "active": false}`
+	is.NoErr(rs.ValidateErr(ok))
+	is.NoErr(rs.ValidateErr(`{"id": 2.0, "user": {"name": "tom", "age": null}, "items": [{"sku": "S1"}]}`))
+
+	tests := []struct{ data, err string }{
+		{`{"user": {"name": "tom"}, "items": [{"sku": "a"}]}`, "id is required"},
+		{`{"id": "1", "user": {"name": "tom"}, "items": [{"sku": "a"}]}`, "id value must be an integer"},
+		{`{"id": 1.5, "user": {"name": "tom"}, "items": [{"sku": "a"}]}`, "id value must be an integer"},
+		{`{"id": -1, "user": {"name": "tom"}, "items": [{"sku": "a"}]}`, "id min value is 1"},
+		{`{"id": 1, "user": {"age": 2}, "items": [{"sku": "a"}]}`, "User name is required"},
+		{`{"id": 1, "user": {"name": "t"}, "items": [{"sku": "a"}]}`, "User name length must be in the range 2 - 8"},
+		{`{"id": 1, "user": {"name": 12}, "items": [{"sku": "a"}]}`, "User name value must be a string"},
+		{`{"id": 1, "user": {"name": "tom", "age": 151}, "items": [{"sku": "a"}]}`, "user.age max value is 150"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"qty": 1}]}`, "items.*.sku is required"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a", "qty": "x"}]}`, "items.*.qty value must be an integer"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "code": "C-123"}`, "code must match pattern ^(A|B)-[0-9]{3}$"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "status": "old"}`, "status value must be in the enum [new paid 3]"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "email": "tom"}`, "email value is an invalid email address"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "contact": {"tel": "1"}}`, "contact.phone is required"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "tags": ["go", 1]}`, "tags.1 value must be a string"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "tags": ["a", "b", "c", "d"]}`, "tags max length is 3"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "matrix": [[1], [2, "3"]]}`, "matrix.1.1 value must be a float"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "labels": {"EN": "hi"}}`, "labels.EN must match pattern ^[a-z]+$"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "labels": {"en": 1}}`, "labels.en value must be a string"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "price": -1}`, "price did not satisfy the rule: (float and min:0) or (string and regexp:^free$)"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "ref": "abc"}`, "ref did not satisfy the rule: int or (string and uuid)"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "nick": "to"}`, "nick min length is 3"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "kind": "cart"}`, "kind value must be in the enum [order]"},
+		{`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "active": "yes"}`, "active value must be a bool"},
+	}
+	for _, tt := range tests {
+		err := rs.ValidateErr(tt.data)
+		is.Err(err, tt.data)
+		if err != nil {
+			is.Eq(tt.err, err.Error(), tt.data)
+		}
+	}
+
+	// the not.enum is notIn
+	r := rs.Validate(`{"id": 1, "user": {"name": "tom"}, "items": [{"sku": "a"}], "tags": ["admin"]}`)
+	is.True(r.Fail())
+	is.Contains(r.Errors.Field("tags.0"), "notIn")
+
+	// the rules can be described
+	f := rs.Describe().Field("user.name")
+	is.NotNil(f)
+	is.Eq("User name", f.Label)
+	is.Eq([]string{"requiredKey", "isString", "stringLength"}, f.Validators())
+}
+
+func TestRuleSetFromJSONSchema_required(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := LoadJSONSchema(strings.NewReader(`{
+		"type": "object",
+		"required": ["name", "count", "items", "opts"],
+		"properties": {
+			"name": {"type": "string"},
+			"count": {"type": "integer"},
+			"items": {"type": "array", "items": {"type": "object", "required": ["sku"]}},
+			"opts": {"type": "object"},
+			"sub": {"type": "object", "required": ["id"]}
+		}
+	}`))
+	is.NoErr(err)
+
+	// the required only check the property exists
+	is.NoErr(rs.ValidateErr(`{"name": "", "count": 0, "items": [], "opts": {}}`))
+	is.NoErr(rs.ValidateErr(`{"name": "", "count": 0, "items": [{"sku": ""}], "opts": {}, "sub": {"id": 0}}`))
+
+	tests := []struct{ data, err string }{
+		{`{"count": 0, "items": [], "opts": {}}`, "name is required"},
+		{`{"name": null, "count": 0, "items": [], "opts": {}}`, "name is required"},
+		{`{"name": "", "count": 0, "opts": {}}`, "items is required"},
+		{`{"name": "", "count": 0, "items": [{"qty": 1}], "opts": {}}`, "items.*.sku is required"},
+		{`{"name": "", "count": 0, "items": [], "opts": {}, "sub": {"name": "a"}}`, "sub.id is required"},
+	}
+	for _, tt := range tests {
+		is.ErrMsg(rs.ValidateErr(tt.data), tt.err, tt.data)
+	}
+
+	// the nested objects are all optional
+	rs, err = LoadJSONSchema(strings.NewReader(`{
+		"properties": {
+			"a": {"type": "object", "properties": {
+				"b": {"type": "object", "required": ["c"]}
+			}}
+		}
+	}`))
+	is.NoErr(err)
+	for i := 0; i < 10; i++ {
+		is.NoErr(rs.ValidateErr(`{"a": {"x": 1}}`))
+	}
+	is.ErrMsg(rs.ValidateErr(`{"a": {"b": {"d": 1}}}`), "a.b.c is required")
+	is.NoErr(rs.ValidateErr(`{"a": {"b": {"c": ""}}}`))
+}
+
+func TestRuleSetFromJSONSchema_errors(t *testing.T) {
+	is := assert.New(t)
+
+	_, err := LoadJSONSchema(strings.NewReader(`{invalid`))
+	is.ErrIs(err, ErrInvalidData)
+
+	_, err = LoadJSONSchema(strings.NewReader(`{"type": "array"}`))
+	is.ErrIs(err, ErrConfig)
+
+	_, err = LoadJSONSchema(strings.NewReader(`{
+		"$defs": {"name": {"type": "string"}},
+		"properties": {
+			"name": {"$ref": "#/$defs/name"},
+			"code": {"type": "string", "pattern": "(a"},
+			"num": {"type": "decimal", "multipleOf": 2},
+			"any": {"oneOf": [{"type": "string"}, {"type": "string", "minLength": 2}]},
+			"sub": {"anyOf": [{"properties": {"a": {}}}]}
+		}
+	}`))
+	is.ErrIs(err, ErrConfig)
+
+	errs := err.(ConfigErrors)
+	is.Len(errs, 7)
+	is.Eq("unsupported JSON Schema keyword '$defs'", errs[0].Error())
+	is.Eq("$ref", errs[3].Validator)
+	is.Eq("name", errs[3].Field)
+	is.StrContains(errs.Error(), "field 'code': invalid pattern")
+	is.StrContains(errs.Error(), "unsupported JSON Schema keyword 'multipleOf'")
+	is.StrContains(errs.Error(), "unknown JSON Schema type 'decimal'")
+	is.StrContains(errs.Error(), "field 'any': the 'oneOf' is only supported")
+	is.StrContains(errs.Error(), "field 'sub': the 'anyOf' sub schema with properties")
+
+	// built by the code, the boolean schemas
+	rs, err := RuleSetFromJSONSchema(&JSONSchema{
+		Properties: map[string]*JSONSchema{
+			"opts": {
+				Type:                 "object",
+				Properties:           map[string]*JSONSchema{"debug": {Type: "boolean"}},
+				AdditionalProperties: &JSONSchema{Not: &JSONSchema{}},
+			},
+		},
+	})
+	is.NoErr(err)
+	is.NoErr(rs.ValidateErr(`{"opts": {"debug": true}}`))
+	is.ErrMsg(rs.ValidateErr(`{"opts": {"debug": "yes"}}`), "opts.debug value must be a bool")
+	is.ErrMsg(rs.ValidateErr(`{"opts": {"debug": true, "x": 1}}`), "opts.x value must be in the enum [debug]")
+}
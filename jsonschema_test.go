@@ -0,0 +1,115 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type JSBase struct {
+	ID string `json:"id" validate:"uuid"`
+}
+
+type jsItem struct {
+	Sku   string  `json:"sku" validate:"required|regexp:^[A-Z]{3}$" label:"SKU"`
+	Price float64 `json:"price" validate:"gt:0"`
+}
+
+type jsOrder struct {
+	JSBase
+	Name    string            `json:"name" validate:"required|minLen:2|maxLen:32"`
+	Age     int               `json:"age" validate:"between:1,120|default:18"`
+	Status  int               `json:"status" validate:"in:1,2,3"`
+	Email   string            `json:"email,omitempty" validate:"email"`
+	Pass    string            `json:"-" validate:"required"`
+	Confirm string            `json:"confirm" validate:"eqField:Name"`
+	Tags    []string          `json:"tags" validate:"maxLen:5|dive|in:go,php"`
+	Labels  map[string]string `json:"labels" validate:"dive|keys|alpha|endkeys|required"`
+	Items   []jsItem          `json:"items" validate:"required"`
+	Created time.Time         `json:"created"`
+}
+
+func TestExportJSONSchema(t *testing.T) {
+	is := assert.New(t)
+
+	s, err := ExportJSONSchema(&jsOrder{})
+	is.NoErr(err)
+	is.Eq(JSONSchemaDraft, s.Schema)
+	is.Eq("jsOrder", s.Title)
+	is.Eq("object", s.Type)
+	is.Eq([]string{"name", "items"}, s.Required)
+
+	// embedded struct is inlined, the "-" field is skipped
+	is.Eq("uuid", s.Properties["id"].Format)
+	is.Nil(s.Properties["Pass"])
+	is.Eq("date-time", s.Properties["created"].Format)
+
+	ps := s.Properties["name"]
+	is.Eq(2, *ps.MinLength)
+	is.Eq(32, *ps.MaxLength)
+
+	ps = s.Properties["age"]
+	is.Eq("integer", ps.Type)
+	is.Eq(1.0, *ps.Minimum)
+	is.Eq(120.0, *ps.Maximum)
+	is.Eq(int64(18), ps.Default)
+	is.Eq([]any{int64(1), int64(2), int64(3)}, s.Properties["status"].Enum)
+	is.Eq("email", s.Properties["email"].Format)
+
+	ps = s.Properties["tags"]
+	is.Eq(5, *ps.MaxItems)
+	is.Nil(ps.MinItems)
+	is.Eq([]any{"go", "php"}, ps.Items.Enum)
+
+	ps = s.Properties["labels"]
+	is.Eq(1, *ps.AdditionalProperties.MinLength)
+	is.NotNil(ps.PropertyNames)
+
+	ps = s.Properties["items"].Items
+	is.Eq([]string{"sku"}, ps.Required)
+	is.Eq("SKU", ps.Properties["sku"].Title)
+	is.Eq("^[A-Z]{3}$", ps.Properties["sku"].Pattern)
+	is.Eq(0.0, *ps.Properties["price"].ExclusiveMinimum)
+
+	// the unmapped rules are reported
+	is.Eq(map[string][]string{
+		"confirm": {"eqField:Name"},
+		"labels":  {"dive|keys|alpha"},
+	}, s.UnmappedRules())
+
+	bs, err := json.Marshal(s.Properties["confirm"])
+	is.NoErr(err)
+	is.Eq(`{"type":"string","x-unmapped-rules":["eqField:Name"]}`, string(bs))
+
+	_, err = ExportJSONSchema(map[string]any{})
+	is.ErrIs(err, ErrInvalidData)
+}
+
+type jsNode struct {
+	Name  string    `json:"name" validate:"required"`
+	Email string    `json:"email" validate:"(email or isCnMobile)|minLen:3" label:"Email"`
+	Role  string    `json:"role" validate:"notIn:root"`
+	Next  *jsNode   `json:"next" validate:"required"`
+	Subs  [2]jsItem `json:"subs"`
+}
+
+func TestExportJSONSchema_more(t *testing.T) {
+	is := assert.New(t)
+
+	s, err := ExportJSONSchema(jsNode{})
+	is.NoErr(err)
+
+	ps := s.Properties["email"]
+	is.Eq("Email", ps.Title)
+	is.Eq(3, *ps.MinLength)
+	is.Eq([]string{"(email or isCnMobile)"}, ps.Unmapped)
+	is.Eq([]any{"root"}, s.Properties["role"].Not.Enum)
+
+	// stop on the recursive type
+	is.Eq([]string{"name", "next"}, s.Required)
+	is.Eq("object", s.Properties["next"].Type)
+	is.Nil(s.Properties["next"].Properties)
+	is.Eq(2, *s.Properties["subs"].MinItems)
+}
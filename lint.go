@@ -0,0 +1,166 @@
+package validate
+
+import "strings"
+
+// TagRule is a validator item of the rule string. see ParseTagRule
+type TagRule struct {
+	// Validator the validator name in the rule. eg: "minLen"
+	Validator string
+	// Name the real validator name. eg: "minLength"
+	Name string
+	// Args the rule arguments, parsed same as the runtime.
+	// eg: "min:1" -> ["1"], "in:a,b" -> [["a", "b"]]
+	Args []any
+	// Elem reports the rule is applied to the elements or keys, after "dive".
+	Elem bool
+}
+
+// ParseTagRule parse the rule string of the validate tag, same as StringRule
+// without the data. The boolean expressions are flattened to the validators.
+// Returns error on the bad dive rules or expression.
+//
+// The registered rule macros are expanded, the unknown macros are skipped since
+// they may be added at runtime.
+//
+// It is used by the static tag linter, see the taglint package.
+func ParseTagRule(rule string) ([]TagRule, error) {
+	if hasRuleMacro(rule) {
+		var errs []*ruleMacroError
+		rule, errs = expandRuleMacros(rule, nil)
+		for _, e := range errs {
+			if e.msg != errMacroNotExists {
+				return nil, e
+			}
+		}
+	}
+
+	var items []TagRule
+	rules := splitRules(strings.Trim(strings.TrimSpace(rule), "|:"))
+	for i, validator := range rules {
+		validator = strings.Trim(validator, ":")
+		if validator == "" {
+			continue
+		}
+
+		if validator == RuleDive {
+			d, err := parseDive(rules[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			return appendDiveItems(items, d), nil
+		}
+
+		// field expression, check the syntax. eg: "expr:end_at > start_at"
+		if name, src, ok := strings.Cut(validator, ":"); ok && ValidatorName(name) == RuleEvalExpr {
+			if _, err := compileEvalExpr(src, "", nil); err != nil {
+				return nil, err
+			}
+			items = append(items, TagRule{Validator: name, Name: RuleEvalExpr, Args: []any{src}})
+			continue
+		}
+
+		if isRuleExpr(validator) {
+			e, err := parseRuleExpr(validator)
+			if err != nil {
+				return nil, err
+			}
+			items = appendExprItems(items, e, false)
+			continue
+		}
+
+		item := TagRule{Validator: validator}
+		if strings.ContainsRune(validator, ':') {
+			list := stringSplit(validator, ":")
+			item.Validator = list[0]
+			item.Name = ValidatorName(list[0])
+			if len(list) > 1 {
+				item.Args = ruleArgs(item.Name, list[1])
+			}
+		} else {
+			item.Name = ValidatorName(validator)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func appendDiveItems(items []TagRule, d *diveRules) []TagRule {
+	for ; d != nil; d = d.next {
+		for _, r := range d.keys {
+			items = appendInlineItem(items, r)
+		}
+		for _, r := range d.elems {
+			items = appendInlineItem(items, r)
+		}
+	}
+	return items
+}
+
+func appendInlineItem(items []TagRule, r *Rule) []TagRule {
+	if r.realName == RuleExprName {
+		return appendExprItems(items, r.arguments[0].(*ruleExpr), true)
+	}
+	return append(items, inlineTagRule(r, true))
+}
+
+func appendExprItems(items []TagRule, e *ruleExpr, elem bool) []TagRule {
+	if e.kind == exprLeaf {
+		return append(items, inlineTagRule(e.rule, elem))
+	}
+	for _, sub := range e.subs {
+		items = appendExprItems(items, sub, elem)
+	}
+	return items
+}
+
+// inlineTagRule build the TagRule from an inline rule. NOTE: the args of a
+// builtin validator may be pre-converted to the typed values.
+func inlineTagRule(r *Rule, elem bool) TagRule {
+	item := TagRule{Validator: r.validator, Name: r.realName, Elem: elem}
+	if len(r.arguments) > 0 {
+		item.Args = make([]any, len(r.arguments))
+		copy(item.Args, r.arguments)
+	}
+	return item
+}
+
+// ValidatorArgs returns the number of the rule args accepted by the validator,
+// maxNum < 0 is no limit. ok is false if the validator is not registered.
+//
+// The validators registered on a Validation, or the struct method validators
+// are not included.
+func ValidatorArgs(name string) (minNum, maxNum int, ok bool) {
+	name = ValidatorName(name)
+	switch name {
+	case RuleSafe, RuleSafe1, RuleOptional, RuleRequired:
+		return 0, 0, true
+	case RuleDefault:
+		return 1, 1, true
+	}
+
+	fm, ok := validatorMetas[name]
+	if !ok {
+		builder, has := ctxValidatorBuilders[name]
+		if !has {
+			return 0, 0, false
+		}
+		fm = newFuncMeta(name, true, builder(newEmpty()))
+	}
+
+	// requiredXXX: the args number is not checked by the runtime
+	if strings.HasPrefix(name, RuleRequired) || fm.style != styleLegacy {
+		return 0, -1, true
+	}
+
+	ft := fm.fv.Type()
+	addNum := 1
+	if ft.NumIn() > 0 && ft.In(0) == dataFaceType {
+		addNum++
+	}
+
+	minNum = fm.numIn - addNum
+	if fm.isVariadic {
+		return minNum - 1, -1, true
+	}
+	return minNum, minNum, true
+}
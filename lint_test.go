@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestParseTagRule(t *testing.T) {
+	is := assert.New(t)
+
+	items, err := ParseTagRule("required|minLen:3|in:a,b|eqField:Pass")
+	is.NoErr(err)
+	is.Len(items, 4)
+	is.Eq("minLen", items[1].Validator)
+	is.Eq("minLength", items[1].Name)
+	is.Eq([]any{"3"}, items[1].Args)
+	is.Eq("enum", items[2].Name)
+	is.Eq([]any{[]string{"a", "b"}}, items[2].Args)
+	is.Eq([]any{"Pass"}, items[3].Args)
+	is.False(items[3].Elem)
+
+	// expression and dive
+	items, err = ParseTagRule("required|(email or isCnMobile)|dive|keys|alpha|endkeys|minLen:2")
+	is.NoErr(err)
+	is.Len(items, 5)
+	is.Eq("isEmail", items[1].Name)
+	is.Eq("isCnMobile", items[2].Name)
+	is.False(items[2].Elem)
+	is.Eq("isAlpha", items[3].Name)
+	is.True(items[3].Elem)
+	is.Eq("minLength", items[4].Name)
+	is.True(items[4].Elem)
+
+	_, err = ParseTagRule("(email or )")
+	is.Err(err)
+	_, err = ParseTagRule("dive|keys|alpha")
+	is.ErrSubMsg(err, "missing 'endkeys'")
+}
+
+func TestValidatorArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+	}{
+		{"required", 0, 0},
+		{"default", 1, 1},
+		{"email", 0, 0},
+		{"min", 1, 1},
+		{"between", 2, 2},
+		{"in", 1, 1},
+		{"int", 0, -1},
+		{"strLen", 1, -1},
+		{"eqField", 1, 1},
+		{"requiredIf", 0, -1},
+	}
+
+	for _, tt := range tests {
+		minNum, maxNum, ok := ValidatorArgs(tt.name)
+		assert.True(t, ok, tt.name)
+		assert.Eq(t, tt.min, minNum, tt.name)
+		assert.Eq(t, tt.max, maxNum, tt.name)
+	}
+
+	_, _, ok := ValidatorArgs("notExists")
+	assert.False(t, ok)
+}
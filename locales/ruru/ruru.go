@@ -53,6 +53,7 @@ var Data = map[string]string{
 	"gt": "Значение {field} должно быть больше %d",
 	// required
 	"required":           "{field} не может быть пустым",
+	"requiredKey":        "{field} обязательно для заполнения",
 	"requiredIf":         "{field} не может быть пустым, когда {args0} равно {args1end}",
 	"requiredUnless":     "{field} не может быть пустым, если {args0} не равно {args1end}",
 	"requiredWith":       "{field} не может быть пустым при наличии {values}",
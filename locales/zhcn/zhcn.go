@@ -31,6 +31,7 @@ var Data = map[string]string{
 	"range": "{field} 值必须在此范围内 %v - %v",
 	// required
 	"required":           "{field} 是必填项",
+	"requiredKey":        "{field} 是必填项",
 	"requiredIf":         "当 {args0} 为 {args1end} 时 {field} 不能为空。",
 	"requiredUnless":     "当 {args0} 不为 {args1end} 时 {field} 不能为空。",
 	"requiredWith":       "当 {values} 存在时 {field} 不能为空。",
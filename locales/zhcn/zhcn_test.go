@@ -1,6 +1,7 @@
 package zhcn
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gookit/goutil/x/assert"
@@ -37,4 +38,9 @@ func TestRegisterGlobal(t *testing.T) {
 	v.AddRule("age", "max", 1)
 	is.False(v.Validate())
 	is.Equal(v.Errors.One(), "age 的最大值是 1")
+
+	// the JSON Schema required
+	rs, err := validate.LoadJSONSchema(strings.NewReader(`{"required": ["name"]}`))
+	is.NoErr(err)
+	is.ErrMsg(rs.ValidateErr(`{}`), "name 是必填项")
 }
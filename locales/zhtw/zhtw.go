@@ -31,6 +31,7 @@ var Data = map[string]string{
 	"range": "{field} 值必須在此範圍內 %v - %v",
 	// required
 	"required":           "{field} 是必填項",
+	"requiredKey":        "{field} 是必填項",
 	"requiredIf":         "當 %v 為 {args} 時 {field} 不能為空。",
 	"requiredUnless":     "當 %v 不為 {args} 時 {field} 不能為空。",
 	"requiredWith":       "當 {values} 存在時 {field} 不能為空。",
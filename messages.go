@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -22,6 +23,14 @@ var (
 
 	ErrEmptyData   = errors.New("please input data use for validate")
 	ErrInvalidData = errors.New("invalid input data")
+
+	// ErrCanceled the validation is stopped by the context canceled or deadline exceeded.
+	// the returned error also wraps the context error. see ValidateCtx
+	ErrCanceled = errors.New("validation canceled")
+	// ErrAsyncTimeout the async validator is timeout. see AddAsyncValidator
+	ErrAsyncTimeout = errors.New("async validator timeout")
+	// ErrConfig the bad rule config error. see ConfigError
+	ErrConfig = errors.New("bad rule config")
 )
 
 // var emptyErrors = Errors{}
@@ -56,32 +65,73 @@ func (es Errors) Add(field, validator, message string) {
 	}
 }
 
-// One returns an random error message text
+// fieldErrorList the errors with details in the occurred order, it is kept
+// with the result. same key and validator: override, like the Errors map.
+type fieldErrorList struct {
+	items []FieldError
+	// {"key validator": index of items}
+	index map[string]int
+}
+
+func (l *fieldErrorList) add(fe FieldError) {
+	key := fe.Name + " " + fe.Alias
+	if i, ok := l.index[key]; ok {
+		l.items[i] = fe
+		return
+	}
+	if l.index == nil {
+		l.index = make(map[string]int)
+	}
+	l.index[key] = len(l.items)
+	l.items = append(l.items, fe)
+}
+
+// reset remove all items. the items are not reused, they may be moved to a result.
+func (l *fieldErrorList) reset() {
+	l.items = nil
+	clear(l.index)
+}
+
+// One returns the first error message text, sorted by the field and the
+// validator. the first occurred error: see ValidResult.One
 func (es Errors) One() string {
-	return es.Random()
+	if fields := es.Fields(); len(fields) > 0 {
+		return es[fields[0]].One()
+	}
+	return ""
 }
 
-// ErrOrNil returns an random error, if no error returns nil
+// ErrOrNil returns the first error, if no error returns nil
 func (es Errors) ErrOrNil() error {
 	return es.OneError()
 }
 
-// OneError returns an random error, if no error returns nil
+// OneError returns the first error, if no error returns nil
 func (es Errors) OneError() error {
 	if len(es) == 0 {
 		return nil
 	}
-	return errorx.Raw(es.Random())
+	return errorx.Raw(es.One())
 }
 
-// Random returns an random error message text
+// Random returns an error message text.
+//
+// Deprecated: it is same as One() now, the result is deterministic.
 func (es Errors) Random() string {
-	if len(es) > 0 {
-		for _, fe := range es {
-			return fe.One()
-		}
+	return es.One()
+}
+
+// Fields returns the field names of the errors, sorted by name.
+//
+// The map does not keep the occurred order, the errors of the validation in the
+// occurred order: see ValidResult.FieldErrors
+func (es Errors) Fields() []string {
+	fields := make([]string, 0, len(es))
+	for field := range es {
+		fields = append(fields, field)
 	}
-	return ""
+	sort.Strings(fields)
+	return fields
 }
 
 // All get all errors data
@@ -93,12 +143,38 @@ func (es Errors) All() map[string]map[string]string {
 	return mm
 }
 
-// JSON encode
+// JSON encode, the fields are sorted by name.
 func (es Errors) JSON() []byte {
-	bts, _ := json.Marshal(es) //nolint:errchkjson
+	bts, _ := es.MarshalJSON()
 	return bts
 }
 
+// MarshalJSON encode the errors, the fields are sorted by name.
+func (es Errors) MarshalJSON() ([]byte, error) {
+	if es == nil {
+		return []byte("null"), nil
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	for i, field := range es.Fields() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(field) //nolint:errchkjson
+		val, err := json.Marshal(map[string]string(es[field]))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
 // Error string get
 func (es Errors) Error() string {
 	return es.String()
@@ -112,7 +188,8 @@ func (es Errors) String() string {
 	}
 
 	buf := new(bytes.Buffer)
-	for field, fe := range es {
+	for _, field := range es.Fields() {
+		fe := es[field]
 		// only one error, return simple format: "field: message"
 		if ln == 1 && len(fe) == 1 {
 			for vName, msg := range fe {
@@ -145,6 +222,59 @@ func (es Errors) FieldOne(field string) string {
 	return ""
 }
 
+// List returns all errors as FieldError values, in the field order and sorted
+// by the validator.
+//
+// The map only has the Field, Name, Validator, Alias and Message. The errors
+// of the validation with the full details: see ValidResult.FieldErrors
+func (es Errors) List() []FieldError {
+	if len(es) == 0 {
+		return nil
+	}
+
+	var list []FieldError
+	for _, field := range es.Fields() {
+		es[field].OrderedRange(func(validator, msg string) {
+			list = append(list, FieldError{
+				Field:     field,
+				Name:      field,
+				Validator: ValidatorName(validator),
+				Alias:     validator,
+				Message:   msg,
+			})
+		})
+	}
+	return list
+}
+
+// FieldError is one validation failure of a field.
+type FieldError struct {
+	// Field the field path in the source data. eg: "Address.ZipCode", "Tags.1"
+	Field string `json:"field"`
+	// Name the output name of the field, it is the key in Errors. eg: "address.zip_code"
+	Name string `json:"name"`
+	// Path the output path by the field tag, the wildcard is replaced with the
+	// element index. eg: "address.zip_code", "items.1.sku"
+	Path string `json:"path,omitempty"`
+	// Label the field display name in the message
+	Label string `json:"label"`
+	// Validator the real validator name. eg: "minLength"
+	Validator string `json:"validator"`
+	// Alias the validator name in the rule. eg: "minLen"
+	Alias string `json:"alias"`
+	// Args the rule arguments
+	Args []any `json:"args,omitempty"`
+	// Value the failing value
+	Value any `json:"value,omitempty"`
+	// Message the rendered error message
+	Message string `json:"message"`
+}
+
+// Error string
+func (fe *FieldError) Error() string {
+	return fe.Message
+}
+
 /*************************************************************
  * Validator error messages
  *************************************************************/
@@ -155,6 +285,9 @@ var builtinMessages = map[string]string{
 	// builtin
 	"_validate": "{field} did not pass validation", // default validate message
 	"_filter":   "{field} data is invalid",         // data filter error
+	// async validator error and timeout
+	"_async":        "{field} could not be validated: %v",
+	"_asyncTimeout": "{field} validation timed out",
 	// int value
 	"min": "{field} min value is %v",
 	"max": "{field} max value is %v",
@@ -187,11 +320,18 @@ var builtinMessages = map[string]string{
 	"range": "{field} value must be in the range %v - %v",
 	// rule-level logical OR (#292): %v renders the sub-validator name list
 	"rule_one_of": "{field} did not satisfy any of: %v",
+	// boolean rule expression: %v renders the expression text
+	"rule_expr": "{field} did not satisfy the rule: %v",
+	// field expression: %v renders the expression text
+	"expr": "{field} did not satisfy the expression: %v",
+	// element rules can only apply to a list or map value
+	"dive": "{field} value must be an array, slice or map",
 	// int compare
 	"lt": "{field} value should be less than %v",
 	"gt": "{field} value should be greater than %v",
 	// required
 	"required":           "{field} is required to not be empty",
+	"requiredKey":        "{field} is required",
 	"requiredIf":         "{field} is required when {args0} is in {args1end}",
 	"requiredUnless":     "{field} field is required unless {args0} is in {args1end}",
 	"requiredWith":       "{field} field is required when {values} is present",
@@ -456,6 +596,14 @@ func (t *Translator) HasMessage(key string) bool {
 
 // Message get by validator name and field name.
 func (t *Translator) Message(validator, field string, args ...any) (msg string) {
+	return t.messageAt(validator, field, field, args...)
+}
+
+// messageAt get error message for the value at path of the field.
+// eg: field "tags", path "tags.1". the message is looked up by the field,
+// and the {field} var is rendered as the field label + the path suffix.
+func (t *Translator) messageAt(validator, field, path string, args ...any) (msg string) {
+	suffix := strings.TrimPrefix(path, field)
 	argLen := len(args)
 	errMsg := t.findMessage(validator, field, argLen)
 	if errMsg == "" {
@@ -466,15 +614,16 @@ func (t *Translator) Message(validator, field string, args ...any) (msg string)
 
 		// not found, fallback - use default error message
 		if errMsg == "" {
-			return t.LabelName(field) + defaultErrMsg
+			return t.LabelName(field) + suffix + defaultErrMsg
 		}
 	}
 
-	return t.format(errMsg, field, args)
+	return t.format(errMsg, field, suffix, args)
 }
 
-// format message for the validator
-func (t *Translator) format(errMsg, field string, args []any) string {
+// format message for the validator. suffix is appended to the field label,
+// it is the element path suffix. eg: ".1"
+func (t *Translator) format(errMsg, field, suffix string, args []any) string {
 	argLen := len(args)
 
 	// fix: #111 argN maybe is a field name.
@@ -511,7 +660,7 @@ func (t *Translator) format(errMsg, field string, args []any) string {
 	}
 
 	// get field display label name.
-	field = t.LabelName(field)
+	field = t.LabelName(field) + suffix
 	if argLen > 0 {
 		// whether you need call fmt.Sprintf
 		if strings.ContainsRune(errMsg, '%') {
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"fmt"
+	"maps"
 	"testing"
 
 	"github.com/gookit/goutil/dump"
@@ -56,6 +57,125 @@ func TestErrorsBasic(t *testing.T) {
 	dump.V(es)
 }
 
+func TestErrors_List(t *testing.T) {
+	is := assert.New(t)
+
+	es := Errors{}
+	is.Empty(es.List())
+
+	es.Add("name", "required", "error msg0")
+	es.Add("age", "min", "error msg1")
+	es.Add("age", "int", "error msg2")
+
+	list := es.List()
+	is.Len(list, 3)
+	is.Eq("age", list[0].Name)
+	is.Eq("int", list[0].Alias)
+	is.Eq("isInt", list[0].Validator)
+	is.Eq("error msg2", list[0].Error())
+	is.Eq("min", list[1].Validator)
+	is.Eq("name", list[2].Field)
+}
+
+func TestErrors_deterministic(t *testing.T) {
+	is := assert.New(t)
+
+	es := Errors{}
+	es.Add("name", "required", "name msg")
+	es.Add("age", "min", "age min msg")
+	es.Add("age", "int", "age int msg")
+	es.Add("email", "email", "email msg")
+
+	for i := 0; i < 20; i++ {
+		is.Eq([]string{"age", "email", "name"}, es.Fields())
+		is.Eq("age int msg", es.One())
+		is.Eq("age int msg", es.Random())
+		is.Eq("age int msg", es.OneError().Error())
+		is.Eq("age:\n int: age int msg\n min: age min msg\nemail:\n email: email msg\nname:\n required: name msg", es.Error())
+	}
+}
+
+type fieldErrUser struct {
+	Name    string `json:"name" validate:"required|minLen:3" label:"User Name"`
+	Address struct {
+		ZipCode string `json:"zip_code" validate:"required|len:6"`
+	} `json:"address" validate:""`
+	Tags []string `json:"tags" validate:"dive|in:go,php"`
+}
+
+func TestValidation_FieldErrors(t *testing.T) {
+	is := assert.New(t)
+
+	u := &fieldErrUser{Name: "ab", Tags: []string{"go", "c"}}
+	u.Address.ZipCode = "1234"
+
+	v := Struct(u)
+	v.StopOnError = false
+	is.False(v.Validate())
+
+	fes := v.FieldErrors()
+	is.Len(fes, 3)
+
+	fe := fes[0]
+	is.Eq("Name", fe.Field)
+	is.Eq("name", fe.Name)
+	is.Eq("User Name", fe.Label)
+	is.Eq("minLength", fe.Validator)
+	is.Eq("minLen", fe.Alias)
+	is.Eq([]any{3}, fe.Args)
+	is.Eq("ab", fe.Value)
+	is.Eq(v.Errors.FieldOne("name"), fe.Message)
+
+	fe = fes[1]
+	is.Eq("Address.ZipCode", fe.Field)
+	is.Eq("address.zip_code", fe.Name)
+	is.Eq("len", fe.Alias)
+	is.Eq("1234", fe.Value)
+
+	// dive element
+	fe = fes[2]
+	is.Eq("Tags.1", fe.Field)
+	is.Eq("tags.1", fe.Name)
+	is.Eq("enum", fe.Validator)
+	is.Eq("in", fe.Alias)
+	is.Eq([]any{[]string{"go", "php"}}, fe.Args)
+	is.Eq("c", fe.Value)
+
+	// keys of the FieldError are the Errors keys
+	for _, fe := range fes {
+		is.Eq(fe.Message, v.Errors[fe.Name][fe.Alias])
+	}
+
+	// the Errors list is built from the map, without the details
+	list := v.Errors.List()
+	is.Len(list, 3)
+	for _, fe := range list {
+		is.Eq(fe.Name, fe.Field)
+		is.Eq(v.Errors[fe.Name][fe.Alias], fe.Message)
+		is.Nil(fe.Value)
+	}
+	v.Errors.Add("name", "custom", "name is invalid")
+	is.Len(v.Errors.List(), 4)
+	is.Len(v.FieldErrors(), 3)
+
+	// moved to the ValidResult, the details are kept on the map copied
+	r := Check(u)
+	is.True(r.Fail())
+	is.Len(r.FieldErrors(), 1)
+	is.Eq("Name", r.FieldErrors()[0].Field)
+	is.Eq("ab", r.FieldErrors()[0].Value)
+	r.Errors = maps.Clone(r.Errors)
+	is.Eq("ab", r.FieldErrors()[0].Value)
+	is.Eq(r.FieldErrors()[0].Message, r.One())
+
+	// custom errors
+	v = Map(M{"name": "tom"})
+	v.AddError("name", "custom", "name is invalid")
+	is.Len(v.FieldErrors(), 1)
+	is.Eq("custom", v.FieldErrors()[0].Validator)
+	is.Nil(v.FieldErrors()[0].Value)
+}
+
 func TestTranslatorBasic(t *testing.T) {
 	tr := NewTranslator()
 
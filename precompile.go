@@ -0,0 +1,335 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gookit/filter"
+)
+
+// builtinFilters the real names of the filters in the gookit/filter.
+// The unknown filter name is ignored by filter.Apply, so check it on Precompile.
+var builtinFilters = map[string]uint8{
+	"int": 1, "uint": 1, "int64": 1, "float": 1, "bool": 1,
+	"unique": 1, "trimStrings": 1, "stringsToInts": 1,
+	"trim": 1, "trimLeft": 1, "trimRight": 1, "title": 1, "email": 1, "substr": 1,
+	"lower": 1, "upper": 1, "lowerFirst": 1, "upperFirst": 1, "upperWord": 1,
+	"snakeCase": 1, "camelCase": 1, "URLEncode": 1, "URLDecode": 1,
+	"escapeJS": 1, "escapeHTML": 1, "strToInts": 1, "strToSlice": 1, "strToTime": 1,
+}
+
+// hasFilter check the filter exists in the validation, global or builtin filters.
+func (v *Validation) hasFilter(name string) bool {
+	if v.FilterFuncValue(name).IsValid() {
+		return true
+	}
+	_, ok := builtinFilters[filter.Name(name)]
+	return ok
+}
+
+// Precompile check the struct types rules at startup, fail fast rather than on
+// the first validating. The types can be a struct value, pointer or reflect.Type.
+//
+// For each type, it collects the rules same as Struct(), and check: the validators
+// and filters exists, the rule args number is match and the args can be converted.
+// Then the rule template of the static type is built and cached.
+//
+// The slice, array or map of struct fields are walked by one element, the element
+// rules are reported with the index 0 or key "*". eg: "Items.0.Name"
+//
+// Returns the ConfigErrors of all types, errors.Is(err, ErrConfig) is true.
+//
+// Usage:
+//
+//	if err := validate.Precompile(User{}, &Order{}); err != nil {
+//		log.Fatal(err)
+//	}
+func Precompile(types ...any) error {
+	var errs ConfigErrors
+	for _, typ := range types {
+		rt, ok := structTypeOf(typ)
+		if !ok {
+			errs = append(errs, &ConfigError{Msg: fmt.Sprintf("Precompile: the type %v is not a struct", rt)})
+			continue
+		}
+		errs = append(errs, precompileType(rt)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// precompileType collect the rules of the struct type in collect config error
+// mode, check them and build the rule template.
+func precompileType(rt reflect.Type) ConfigErrors {
+	tm := getTypeMeta(rt)
+	d := &StructData{
+		meta:        tm,
+		valueTyp:    rt,
+		ValidateTag: gOpt.ValidateTag,
+		FilterTag:   gOpt.FilterTag,
+		fieldNames:  make(map[string]int8),
+	}
+	d.value = filledValue(rt, map[reflect.Type]bool{rt: true})
+	d.src = d.value.Addr().Interface()
+
+	v := newEmpty()
+	v.data = d
+	v.CollectConfigErr = true
+	err := catchConfigPanic(v, func() {
+		d.parseRulesFromTag(v)
+		if tm.implConfig {
+			d.value.MethodByName("ConfigValidation").Call([]reflect.Value{reflect.ValueOf(v)})
+		}
+	})
+	if err == nil {
+		err = v.Precompile()
+	}
+
+	var errs ConfigErrors
+	if err != nil {
+		errors.As(err, &errs)
+		for _, e := range errs {
+			e.Type = rt.String()
+		}
+	}
+
+	// build the template only on no errors, the bad rules will panic on building.
+	if tm.isStatic && (len(errs) == 0 || gOpt.CollectConfigErr) {
+		tm.staticTemplate()
+	}
+	return errs
+}
+
+// catchConfigPanic run fn, the panic is collected as a config error.
+// eg: panic on add invalid validator func in the ConfigValidation.
+func catchConfigPanic(v *Validation, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			v.configErrs = append(v.configErrs, &ConfigError{Msg: strings.TrimPrefix(fmt.Sprint(r), "validate: ")})
+			err = v.Err()
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+// filledValue new an addressable value of the struct type, the struct pointer
+// is allocated, and one element is added to the slice or map of struct, so all
+// the rules of the sub struct can be collected. ancestors is used to stop on
+// the recursive types.
+func filledValue(rt reflect.Type, ancestors map[reflect.Type]bool) reflect.Value {
+	rv := reflect.New(rt).Elem()
+	fillStruct(rv, ancestors)
+	return rv
+}
+
+func fillStruct(rv reflect.Value, ancestors map[reflect.Type]bool) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		fv := rv.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+		if ev, ok := filledElem(fv.Type(), ancestors); ok {
+			fv.Set(ev)
+		}
+	}
+}
+
+// filledElem returns the filled value for the field type, ok is false if the
+// type does not contain struct.
+func filledElem(ft reflect.Type, ancestors map[reflect.Type]bool) (reflect.Value, bool) {
+	et := removeTypePtr(ft)
+	switch ft.Kind() {
+	case reflect.Ptr:
+		if et.Kind() != reflect.Struct || et == timeType || ancestors[et] {
+			return emptyValue, false
+		}
+		ancestors[et] = true
+		ptr := reflect.New(et)
+		fillStruct(ptr.Elem(), ancestors)
+		delete(ancestors, et)
+		return ptr, true
+	case reflect.Struct:
+		if ft == timeType || ancestors[ft] {
+			return emptyValue, false
+		}
+		ancestors[ft] = true
+		sv := reflect.New(ft).Elem()
+		fillStruct(sv, ancestors)
+		delete(ancestors, ft)
+		return sv, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem, ok := filledElem(ft.Elem(), ancestors)
+		if !ok {
+			return emptyValue, false
+		}
+
+		switch ft.Kind() {
+		case reflect.Slice:
+			return reflect.Append(reflect.MakeSlice(ft, 0, 1), elem), true
+		case reflect.Array:
+			av := reflect.New(ft).Elem()
+			for i := 0; i < av.Len(); i++ {
+				av.Index(i).Set(elem)
+			}
+			return av, true
+		}
+
+		key := reflect.New(ft.Key()).Elem()
+		if key.Kind() == reflect.String {
+			key.SetString("*")
+		}
+		mv := reflect.MakeMapWithSize(ft, 1)
+		mv.SetMapIndex(key, elem)
+		return mv, true
+	}
+	return emptyValue, false
+}
+
+// Precompile check all rules are valid before validating: the validators and
+// filters exists, the rule args number is match and the args can be converted.
+// The bad rules are collected even if CollectConfigErr is false.
+//
+// Returns same as Err(). Usage:
+//
+//	v := validate.Map(data)
+//	v.StringRule("name", "required|minLen:2")
+//	if err := v.Precompile(); err != nil {
+//		// bad rules config
+//	}
+func (v *Validation) Precompile() error {
+	collect := v.CollectConfigErr
+	v.CollectConfigErr = true
+	defer func() { v.CollectConfigErr = collect }()
+
+	v.precompileRules()
+	return v.Err()
+}
+
+// precompileRules check all the validator and filter rules, the bad rules are
+// collected by configErrorf.
+func (v *Validation) precompileRules() {
+	for _, r := range v.rules {
+		r.precompile(v)
+	}
+
+	for _, r := range v.filterRules {
+		r.precompile(v)
+	}
+}
+
+// precompile check the filters of the rule exists.
+func (r *FilterRule) precompile(v *Validation) {
+	field := strings.Join(r.fields, ",")
+	for _, name := range r.filters {
+		if !v.hasFilter(name) {
+			v.configErrorf(field, name, "the filter '%s' does not exist", name)
+		}
+	}
+}
+
+// precompile resolve the rule validator, check the args number and convert
+// the args, same as the runtime checks in valueValidate.
+func (r *Rule) precompile(v *Validation) {
+	r.precompileAs(v, strings.Join(r.fields, ","))
+}
+
+func (r *Rule) precompileAs(v *Validation, field string) {
+	name := r.realName
+	switch name {
+	case RuleSafe, RuleSafe1, RuleOptional, RuleRequired, RuleDefault:
+		return
+	case RuleDive:
+		// check the inline rules for the keys and elements
+		for d := r.diveRulesOf(v); d != nil; d = d.next {
+			for _, sub := range d.keys {
+				sub.precompileAs(v, field)
+			}
+			for _, sub := range d.elems {
+				sub.precompileAs(v, field)
+			}
+		}
+		return
+	case RuleExprName:
+		if e := r.ruleExprOf(v, field); e != nil {
+			e.precompile(v, field)
+		}
+		return
+	}
+	if isFileValidator(name) {
+		return
+	}
+
+	fm := r.checkFuncMeta
+	if fm == nil {
+		if fm = v.validatorMeta(name); fm == nil {
+			v.configErrorf(field, r.validator, "the validator '%s' does not exist", r.validator)
+			return
+		}
+	}
+
+	if fm.style == styleAsync && r.inExpr {
+		v.configErrorf(field, r.validator, "the async validator '%s' can not be used in the rule expression", r.validator)
+		return
+	}
+	if !r.nameNotRequired || fm.style != styleLegacy {
+		return
+	}
+
+	addNum := 1
+	if fm.fv.Type().In(0) == dataFaceType {
+		addNum++
+	}
+	if err := fm.checkArgNum(len(r.arguments)+addNum, r.validator); err != nil {
+		v.configErrorf(field, r.validator, "%s", err.Error())
+		return
+	}
+
+	if r.argsReady {
+		return
+	}
+
+	// convert on a copy, the runtime will convert the rule args.
+	args := make([]any, len(r.arguments))
+	copy(args, r.arguments)
+	if err := convertRuleArgs(fm, field, args, addNum); err != nil {
+		v.configErrorf(field, r.validator, "%s, validator '%s'", err.Error(), r.validator)
+	}
+}
+
+// ruleExprOf get the compiled expression from the rule arguments.
+func (r *Rule) ruleExprOf(v *Validation, field string) *ruleExpr {
+	if len(r.arguments) > 0 {
+		switch arg := r.arguments[0].(type) {
+		case *ruleExpr:
+			return arg
+		case string: // add by AddRule(field, "rule_expr", "email or int")
+			e, err := parseRuleExpr(arg)
+			if err != nil {
+				v.configErrorf(field, RuleExprName, "%s", err.Error())
+			}
+			return e
+		}
+	}
+
+	v.configErrorf(field, RuleExprName, "the validator '%s' requires a rule expression argument", RuleExprName)
+	return nil
+}
+
+// precompile check the leaf rules of the expression
+func (e *ruleExpr) precompile(v *Validation, field string) {
+	if e.kind == exprLeaf {
+		e.rule.precompileAs(v, field)
+		return
+	}
+	for _, sub := range e.subs {
+		sub.precompile(v, field)
+	}
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type pcItem struct {
+	Sku string `validate:"required|notExists"`
+	Num string `validate:"minLen:a"`
+}
+
+type pcOrder struct {
+	ID    string            `validate:"required|minLen:3" filter:"trim|badFilter"`
+	Tags  []string          `validate:"dive|(alpha or nope)"`
+	Items []pcItem          `validate:"required"`
+	Meta  map[string]pcItem `validate:"required"`
+	Next  *pcOrder          `validate:"-"`
+}
+
+type pcGood struct {
+	Name  string `json:"name" validate:"required|minLen:2" filter:"trim|lower"`
+	Email string `json:"email" validate:"email"`
+	Age   int    `json:"age" validate:"between:1,120"`
+}
+
+func TestPrecompile(t *testing.T) {
+	is := assert.New(t)
+
+	is.NoErr(Precompile(pcGood{}, &pcGood{}, reflect.TypeOf(pcGood{})))
+	// the template is built
+	tm := getTypeMeta(reflect.TypeOf(pcGood{}))
+	is.True(tm.isStatic)
+	is.NotNil(tm.tpl)
+
+	err := Precompile(&pcOrder{}, "not struct")
+	is.ErrIs(err, ErrConfig)
+	ces := err.(ConfigErrors)
+
+	var got []string
+	for _, e := range ces {
+		got = append(got, e.Error())
+	}
+	is.Eq([]string{
+		"validate.pcOrder: field 'Tags': the validator 'nope' does not exist",
+		"validate.pcOrder: field 'Items.0.Sku': the validator 'notExists' does not exist",
+		"validate.pcOrder: field 'Items.0.Num': cannot convert string to arg#1(int), validator 'minLen'",
+		"validate.pcOrder: field 'Meta.*.Sku': the validator 'notExists' does not exist",
+		"validate.pcOrder: field 'Meta.*.Num': cannot convert string to arg#1(int), validator 'minLen'",
+		// the recursive type is walked one level
+		"validate.pcOrder: field 'Next.Tags': the validator 'nope' does not exist",
+		"validate.pcOrder: field 'ID': the filter 'badFilter' does not exist",
+		"validate.pcOrder: field 'Next.ID': the filter 'badFilter' does not exist",
+		"Precompile: the type string is not a struct",
+	}, got)
+}
+
+type pcStatic struct {
+	Name string `validate:"required|(email or )"`
+}
+
+func TestPrecompile_static(t *testing.T) {
+	is := assert.New(t)
+
+	err := Precompile(pcStatic{})
+	is.ErrSubMsg(err, "validate.pcStatic: field 'Name': invalid rule expression")
+	// the bad template is not built
+	tm := getTypeMeta(reflect.TypeOf(pcStatic{}))
+	is.Nil(tm.tpl)
+	is.Panics(func() {
+		Struct(&pcStatic{})
+	})
+}
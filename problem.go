@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType the media type of the problem details document. RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document of the validation errors.
+//
+// Usage:
+//
+//	r := validate.Check(&user)
+//	if r.Fail() {
+//		r.Problem().ServeHTTP(w, req)
+//		return
+//	}
+type Problem struct {
+	// Type a URI reference that identifies the problem type. default: "about:blank"
+	Type string `json:"type"`
+	// Title a short summary of the problem type.
+	Title string `json:"title"`
+	// Status the HTTP status code. default: 422
+	Status int `json:"status"`
+	// Detail an explanation of this problem occurrence.
+	Detail string `json:"detail,omitempty"`
+	// Instance a URI reference that identifies this problem occurrence.
+	Instance string `json:"instance,omitempty"`
+	// Errors extension member: the failures of the fields
+	Errors []ProblemError `json:"errors"`
+}
+
+// ProblemError is one field failure in the Problem.Errors
+type ProblemError struct {
+	// Pointer the JSON pointer(RFC 6901) of the field output path. eg: "/address/zip_code", "/tags/1"
+	//
+	// it is "" for the errors not belong to a field.
+	Pointer string `json:"pointer"`
+	// Code the real validator name. eg: "required", "minLength"
+	Code string `json:"code"`
+	// Message the error message
+	Message string `json:"message"`
+	// Args the rule arguments
+	Args []any `json:"args,omitempty"`
+}
+
+// NewProblem create a problem document from the field errors.
+func NewProblem(fes []FieldError) *Problem {
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Errors: make([]ProblemError, 0, len(fes)),
+	}
+
+	for _, fe := range fes {
+		path := fe.Path
+		if path == "" { // the errors built by hand
+			path = fe.Name
+		}
+
+		p.Errors = append(p.Errors, ProblemError{
+			Pointer: jsonPointer(path),
+			Code:    fe.Validator,
+			Message: fe.Message,
+			Args:    fe.Args,
+		})
+	}
+
+	if len(fes) > 0 {
+		p.Detail = fes[0].Message
+	}
+	return p
+}
+
+// Problem build an RFC 7807 problem document from the errors, sorted by the
+// field. see List()
+func (es Errors) Problem() *Problem {
+	return NewProblem(es.List())
+}
+
+// Problem build an RFC 7807 problem document from the result errors,
+// in the order they occurred.
+func (r *ValidResult) Problem() *Problem {
+	return NewProblem(r.FieldErrors())
+}
+
+// JSON encode the problem document by the package Marshal func.
+func (p *Problem) JSON() ([]byte, error) {
+	return Marshal(p)
+}
+
+// Write the problem document to the response, with the status and content type.
+func (p *Problem) Write(w http.ResponseWriter) error {
+	bts, err := p.JSON()
+	if err != nil {
+		return err
+	}
+	return p.write(w, bts)
+}
+
+// ServeHTTP implements the http.Handler, write the problem document to the response.
+func (p *Problem) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	bts, err := p.JSON()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_ = p.write(w, bts)
+}
+
+func (p *Problem) write(w http.ResponseWriter, bts []byte) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, err := w.Write(bts)
+	return err
+}
+
+// WriteProblem write the errors as an RFC 7807 problem document to the response.
+// returns false if the result has no error, nothing is written.
+//
+// Usage:
+//
+//	if validate.WriteProblem(w, validate.Check(&user)) {
+//		return
+//	}
+func WriteProblem(w http.ResponseWriter, r *ValidResult) bool {
+	if r.IsOK() {
+		return false
+	}
+
+	r.Problem().ServeHTTP(w, nil)
+	return true
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// jsonPointer convert the output path to the JSON pointer(RFC 6901).
+// eg: "address.zip_code" -> "/address/zip_code"
+func jsonPointer(key string) string {
+	if key == "" || key == validateError || key == filterError || key == contextError || key == configError {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, node := range strings.Split(key, ".") {
+		sb.WriteByte('/')
+		sb.WriteString(jsonPointerEscaper.Replace(node))
+	}
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestJsonPointer(t *testing.T) {
+	is := assert.New(t)
+
+	is.Eq("/name", jsonPointer("name"))
+	is.Eq("/address/zip_code", jsonPointer("address.zip_code"))
+	is.Eq("/tags/1", jsonPointer("tags.1"))
+	is.Eq("/a~1b/c~0d", jsonPointer("a/b.c~d"))
+	is.Eq("", jsonPointer(validateError))
+	is.Eq("", jsonPointer(""))
+}
+
+func TestErrors_Problem(t *testing.T) {
+	is := assert.New(t)
+
+	es := Errors{}
+	es.Add("name", "required", "name is required")
+	es.Add("age", "min", "age min value is 1")
+
+	p := es.Problem()
+	is.Eq("about:blank", p.Type)
+	is.Eq(http.StatusUnprocessableEntity, p.Status)
+	is.Eq("Unprocessable Entity", p.Title)
+	is.Len(p.Errors, 2)
+	is.Eq("/age", p.Errors[0].Pointer)
+	is.Eq("min", p.Errors[0].Code)
+	is.Eq("age min value is 1", p.Detail)
+
+	bts, err := p.JSON()
+	is.NoErr(err)
+	is.StrContains(string(bts), `"errors":[{"pointer":"/age","code":"min","message":"age min value is 1"}`)
+}
+
+func TestValidResult_Problem(t *testing.T) {
+	is := assert.New(t)
+
+	u := &checkUser{Name: "ab", Email: "bad", Age: 20}
+	v := Struct(u)
+	v.StopOnError = false
+	r := v.ValidateR()
+
+	w := httptest.NewRecorder()
+	is.True(WriteProblem(w, r))
+	is.Eq(http.StatusUnprocessableEntity, w.Code)
+	is.Eq(ProblemContentType, w.Header().Get("Content-Type"))
+
+	var doc map[string]any
+	is.NoErr(json.Unmarshal(w.Body.Bytes(), &doc))
+	is.Eq("Unprocessable Entity", doc["title"])
+	is.Eq(float64(422), doc["status"])
+
+	list := doc["errors"].([]any)
+	is.Len(list, 2)
+	first := list[0].(map[string]any)
+	// in the occurred order, with the rule args
+	is.Eq("/name", first["pointer"])
+	is.Eq("minLength", first["code"])
+	is.Eq([]any{float64(3)}, first["args"])
+
+	// no error
+	w = httptest.NewRecorder()
+	is.False(WriteProblem(w, Check(&checkUser{Name: "tom", Email: "tom@example.com", Age: 20})))
+	is.Eq(0, w.Body.Len())
+
+	// custom Marshal hook
+	old := Marshal
+	defer func() { Marshal = old }()
+	Marshal = func(v any) ([]byte, error) { return nil, errors.New("marshal error") }
+
+	w = httptest.NewRecorder()
+	r.Problem().ServeHTTP(w, nil)
+	is.Eq(http.StatusInternalServerError, w.Code)
+	is.Err(r.Problem().Write(httptest.NewRecorder()))
+}
+
+type problemItem struct {
+	Sku string `json:"sku" validate:"required"`
+}
+
+type problemAddress struct {
+	ZipCode string `json:"zip_code" validate:"required"`
+	City    string
+}
+
+type problemOrder struct {
+	Address problemAddress `json:"address" validate:""`
+	Items   []*problemItem `json:"items" validate:"slice"`
+}
+
+func TestNewProblem_pointer(t *testing.T) {
+	is := assert.New(t)
+
+	// the struct: json names with the concrete element index
+	o := &problemOrder{Items: []*problemItem{{Sku: "a1"}, {}}}
+	v := Struct(o)
+	v.StopOnError = false
+	p := v.ValidateR().Problem()
+	is.Len(p.Errors, 2)
+	is.Eq("/address/zip_code", p.Errors[0].Pointer)
+	is.Eq("required", p.Errors[0].Code)
+	is.Eq("/items/1/sku", p.Errors[1].Pointer)
+
+	// the map data with wildcard
+	v = Map(map[string]any{
+		"items": []any{
+			map[string]any{"sku": "a1"},
+			map[string]any{"sku": "b"},
+		},
+	})
+	v.StringRule("items.*.sku", "minLen:2")
+	p = v.ValidateR().Problem()
+	is.Len(p.Errors, 1)
+	is.Eq("/items/1/sku", p.Errors[0].Pointer)
+	is.Eq("minLength", p.Errors[0].Code)
+}
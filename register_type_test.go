@@ -64,6 +64,27 @@ func TestAddCustomType_NullString(t *testing.T) {
 	})
 }
 
+func TestAddCustomType_elements(t *testing.T) {
+	ResetCustomTypes()
+	defer ResetCustomTypes()
+	registerNullString()
+
+	names := []sql.NullString{{Valid: true, String: "tom"}, {Valid: false, String: "x"}}
+
+	// the dive and wildcard elements are resolved same as the field value
+	err := Val(names, "dive|required")
+	assert.Err(t, err)
+	assert.StrContains(t, err.Error(), "input.1 is required")
+
+	v := Map(map[string]any{"users": []any{map[string]any{"name": names[0]}, map[string]any{"name": names[1]}}})
+	v.StringRule("users.*.name", "required")
+	assert.False(t, v.Validate())
+	assert.True(t, v.Errors.HasField("users.*.name"))
+
+	names[1].Valid = true
+	assert.NoErr(t, Val(names, "dive|required|minLen:1"))
+}
+
 func TestAddCustomType_Money(t *testing.T) {
 	ResetCustomTypes()
 	defer ResetCustomTypes()
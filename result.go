@@ -3,6 +3,7 @@ package validate
 import (
 	"strings"
 
+	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/goutil/maputil"
 )
 
@@ -24,6 +25,12 @@ type ValidResult struct {
 	// helpers IsOK()/Fail()/Err() are provided instead of an Errors() method
 	// (which would collide with the field name).
 	Errors Errors
+	// the errors with details in the occurred order
+	fieldErrors []FieldError
+	// the context done error, see ValidateCtx
+	ctxErr error
+	// the bad rules config errors, see Validation.CollectConfigErr
+	configErr error
 
 	// validated safe data. mirrors the old Validation.safeData.
 	safeData M
@@ -37,8 +44,40 @@ func (r *ValidResult) IsOK() bool { return r.Errors.Empty() }
 // Fail reports whether validation failed (has at least one error).
 func (r *ValidResult) Fail() bool { return !r.Errors.Empty() }
 
-// Err returns a (random) error if validation failed, otherwise nil.
-func (r *ValidResult) Err() error { return r.Errors.OneError() }
+// Err returns the first occurred error if validation failed, otherwise nil.
+//
+// If the validation is stopped by the context, returns the error wraps
+// ErrCanceled and the context error. If has bad rules config, returns the
+// ConfigErrors wraps ErrConfig.
+func (r *ValidResult) Err() error {
+	if r.configErr != nil {
+		return r.configErr
+	}
+	if r.ctxErr != nil {
+		return r.ctxErr
+	}
+	if fes := r.FieldErrors(); len(fes) > 0 {
+		return errorx.Raw(fes[0].Message)
+	}
+	return nil
+}
+
+// One returns the first occurred error message, "" if validation passed.
+func (r *ValidResult) One() string {
+	if fes := r.FieldErrors(); len(fes) > 0 {
+		return fes[0].Message
+	}
+	return ""
+}
+
+// FieldErrors returns all errors with the details, in the order they occurred.
+func (r *ValidResult) FieldErrors() []FieldError {
+	if len(r.fieldErrors) > 0 {
+		return r.fieldErrors
+	}
+	// the errors added to the Errors map directly
+	return r.Errors.List()
+}
 
 // SafeData returns all validated safe data.
 func (r *ValidResult) SafeData() M { return r.safeData }
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"maps"
 	"sync"
 	"testing"
 
@@ -72,6 +73,54 @@ func TestValidateR_struct(t *testing.T) {
 	assert.True(t, r.Fail())
 }
 
+func TestCheck_errorOrder(t *testing.T) {
+	is := assert.New(t)
+
+	// struct source: errors are in the field declaration order
+	u := &checkUser{Name: "ab", Email: "bad", Age: 200}
+	for i := 0; i < 10; i++ {
+		v := Struct(u)
+		v.StopOnError = false
+		r := v.ValidateR()
+		is.True(r.Fail())
+		is.Len(r.FieldErrors(), 3)
+		is.Eq("name", r.FieldErrors()[0].Name)
+		is.Eq("email", r.FieldErrors()[1].Name)
+		is.Eq("age", r.FieldErrors()[2].Name)
+		is.Eq(r.Errors.FieldOne("name"), r.One())
+		is.Eq(r.One(), r.Err().Error())
+	}
+	is.Eq("name min length is 3", CheckErr(u).Error())
+
+	// map source: errors are in the rule order
+	for i := 0; i < 10; i++ {
+		v := Map(M{"b": "", "a": ""})
+		v.StopOnError = false
+		v.StringRule("b", "required")
+		v.StringRule("a", "required")
+		r := v.ValidateR()
+		is.Eq("b", r.FieldErrors()[0].Name)
+		is.Eq("b is required to not be empty", r.Err().Error())
+		is.Eq("b is required to not be empty", r.One())
+		// the Errors map is sorted by the field
+		is.Eq("a is required to not be empty", r.Errors.One())
+		is.Eq([]string{"a", "b"}, r.Errors.Fields())
+		is.Eq(`{"a":{"required":"a is required to not be empty"},"b":{"required":"b is required to not be empty"}}`, string(r.Errors.JSON()))
+		is.Eq("a:\n required: a is required to not be empty\nb:\n required: b is required to not be empty", r.Errors.String())
+	}
+
+	// same errors on the map copied
+	es := maps.Clone(Check(u).Errors)
+	is.Eq(Errors{"name": Check(u).Errors["name"]}, es)
+	is.Eq(CheckErr(u).Error(), es.One())
+
+	// the map built by hand is sorted
+	es = Errors{"b": MS{"required": "b error"}, "a": MS{"required": "a error"}}
+	is.Eq("a error", es.One())
+	es.Add("c", "min", "c error")
+	is.Eq([]string{"a", "b", "c"}, es.Fields())
+}
+
 // ValidateR on a map + programmatic rules (the documented map path).
 func TestValidateR_map(t *testing.T) {
 	v := Map(map[string]any{"age": 100, "name": "inhere"})
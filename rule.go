@@ -1,7 +1,10 @@
 package validate
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/gookit/validate/v2/internal/fieldval"
 )
 
 // Rules definition
@@ -48,6 +51,8 @@ type Rule struct {
 	filterFunc func(val any) (any, error)
 	// custom check function's mate info
 	checkFuncMeta *funcMeta
+	// is a leaf of the rule expression, the async validator can not be used.
+	inExpr bool
 	// custom check is empty. TODO
 	// emptyChecker func(val any) bool
 }
@@ -134,6 +139,65 @@ func (r *Rule) Fields() []string {
 	return r.fields
 }
 
+// newInlineRule build a standalone validator rule, which is not added to a
+// Validation but evaluated by its parent rule. eg: the leaves of a boolean
+// expression, the element rules of a dive.
+func newInlineRule(validator, argStr string, hasArgs bool) (*Rule, error) {
+	realName := ValidatorName(validator)
+	switch realName {
+	case RuleDefault, RuleOptional, RuleDive, RuleKeys, RuleEndKeys:
+		return nil, fmt.Errorf("validator '%s' is not allowed here", validator)
+	}
+
+	var args []any
+	if hasArgs {
+		args = ruleArgs(realName, argStr)
+	}
+	// the field expression is untyped in the inline rule. eg: "dive|expr:$ > 0"
+	if realName == RuleEvalExpr && hasArgs {
+		e, err := compileEvalExpr(argStr, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		args = []any{e}
+	}
+
+	r := &Rule{
+		validator: validator,
+		realName:  realName,
+		arguments: args,
+		// validator name is not "requiredXXX"
+		nameNotRequired: !strings.HasPrefix(realName, RuleRequired),
+	}
+
+	// pre-convert args for global builtin validators, same as the static
+	// template does (preConvertTemplateArgs). others convert at runtime.
+	if fm, ok := validatorMetas[realName]; ok && fm.builtin && len(args) > 0 {
+		addNum := 1
+		if fm.fv.Type().In(0) == dataFaceType {
+			addNum++
+		}
+		if convertRuleArgs(fm, "", r.arguments, addNum) == nil {
+			r.argsReady = true
+		}
+	}
+	return r, nil
+}
+
+// inlineValidate validate the value by an inline rule (see newInlineRule).
+//
+// The runtime converts not-ready args in place, so it validates on a per-call
+// copy to keep the shared inline rule read-only.
+func (r *Rule) inlineValidate(field string, fv *fieldval.FieldValue, v *Validation) bool {
+	if !r.argsReady && len(r.arguments) > 0 {
+		cp := *r
+		cp.arguments = make([]any, len(r.arguments))
+		copy(cp.arguments, r.arguments)
+		return cp.valueValidate(field, cp.realName, fv, v)
+	}
+	return r.valueValidate(field, r.realName, fv, v)
+}
+
 func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string) {
 	if r.messages != nil {
 		var ok bool
@@ -156,6 +220,45 @@ func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string)
 	return v.trans.Message(validator, field, r.arguments...)
 }
 
+// errorArgs returns a copy of the rule arguments for FieldError. the compiled
+// expression is converted to its text.
+func (r *Rule) errorArgs() []any {
+	if len(r.arguments) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(r.arguments))
+	for i, arg := range r.arguments {
+		switch e := arg.(type) {
+		case *ruleExpr:
+			arg = e.String()
+		case *evalExpr:
+			arg = e.String()
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// errorMessageAt get the error message for an element of the field.
+// eg: path "tags.1" of the field "tags". messages are looked up by the field.
+func (r *Rule) errorMessageAt(field, path, validator string, args []any, v *Validation) (msg string) {
+	if r.messages != nil {
+		var ok bool
+		if msg, ok = r.messages[field+"."+validator]; ok {
+			return
+		}
+		if msg, ok = r.messages[field]; ok {
+			return
+		}
+	}
+
+	if r.message != "" {
+		return r.message
+	}
+	return v.trans.messageAt(validator, field, path, args...)
+}
+
 /*************************************************************
  * add validate rules
  *************************************************************/
@@ -168,7 +271,43 @@ func (r *Rule) errorMessage(field, validator string, v *Validation) (msg string)
 //	// will try convert to int before applying validation.
 //	v.StringRule("age", "required|int|min:12", "toInt")
 //	v.StringRule("email", "required|min_len:6", "trim|email|lower")
+//
+// A rule segment can also be a boolean expression of validators, grouped by
+// parentheses and combined with "and", "or", "not"(or "!"):
+//
+//	v.StringRule("contact", "required|(email or (isCnMobile and len:11))|not contains:admin")
+//
+// The rules after a "dive" segment apply to each element of a slice, array or
+// map, "keys ... endkeys" right after "dive" apply to each map key. Errors are
+// keyed by the element path. eg: "tags.1", "labels.en"
+//
+//	v.StringRule("tags", "required|dive|in:go,php")
+//	v.StringRule("matrix", "dive|required|dive|int|min:1")
+//	v.StringRule("labels", "dive|keys|alpha|endkeys|required")
+//
+// The bad dive rules or expression will panic, or be collected on
+// CollectConfigErr is true. see Err()
+//
+// A "@name" segment is a rule macro (see AddRuleMacro), it is expanded to the
+// macro rules and filters:
+//
+//	v.StringRule("name", "@username|notIn:admin")
 func (v *Validation) StringRule(field, rule string, filterRule ...string) *Validation {
+	if hasRuleMacro(rule) {
+		var macroFilter string
+		rule, macroFilter = v.expandRuleMacros(field, rule)
+		if macroFilter != "" {
+			if len(filterRule) > 0 {
+				macroFilter = joinFilterRule(macroFilter, filterRule[0])
+			}
+			filterRule = []string{macroFilter}
+		}
+	}
+	return v.stringRule(field, rule, filterRule...)
+}
+
+// stringRule add the rules of the field, the macros in the rule are already expanded.
+func (v *Validation) stringRule(field, rule string, filterRule ...string) *Validation {
 	rule = strings.TrimSpace(rule)
 	if rule == "" {
 		if len(filterRule) > 0 {
@@ -178,33 +317,50 @@ func (v *Validation) StringRule(field, rule string, filterRule ...string) *Valid
 	}
 
 	rules := splitRules(strings.Trim(rule, "|:"))
-	for _, validator := range rules {
+	for i, validator := range rules {
 		validator = strings.Trim(validator, ":")
 		if validator == "" { // empty
 			continue
 		}
 
+		// the rest rules apply to each element. eg: "dive|keys|alpha|endkeys|required"
+		if validator == RuleDive {
+			if d, err := parseDive(rules[i+1:]); err != nil {
+				v.configErrorf(field, RuleDive, "%s", err.Error())
+			} else {
+				v.addOneRule(field, RuleDive, RuleDive, []any{d})
+			}
+			break
+		}
+
+		// field expression, the arg is the raw text. eg: "expr:end_at > start_at"
+		if name, src, ok := strings.Cut(validator, ":"); ok && ValidatorName(name) == RuleEvalExpr {
+			v.addEvalExpr(field, name, src)
+			continue
+		}
+
+		// boolean expression. eg: "(email or isCnMobile)", "not contains:admin"
+		if isRuleExpr(validator) {
+			if e, err := parseRuleExpr(validator); err != nil {
+				v.configErrorf(field, RuleExprName, "%s", err.Error())
+			} else {
+				v.addOneRule(field, RuleExprName, RuleExprName, []any{e})
+			}
+			continue
+		}
+
 		// has args "min:12"
 		if strings.ContainsRune(validator, ':') {
 			list := stringSplit(validator, ":")
 			// reassign value
 			validator := list[0]
 			realName := ValidatorName(validator)
-			switch realName {
 			// add default value for the field
-			case RuleDefault:
+			if realName == RuleDefault {
 				v.SetDefValue(field, list[1])
-			// eg 'regex:\d{4,6}' dont need split args. args is "\d{4,6}"
-			case RuleRegexp:
-				v.AddRule(field, validator, list[1])
-			// some special validator. need merge args to one.
-			// "rule_one_of" (#292) also收集为单个 []string 列表参数, 子项为校验器名。
-			case "enum", "notIn", "rule_one_of":
-				v.AddRule(field, validator, parseArgString(list[1]))
-			default:
-				args := parseArgString(list[1])
-				v.AddRule(field, validator, strings2Args(args)...)
+				continue
 			}
+			v.AddRule(field, validator, ruleArgs(realName, list[1])...)
 		} else {
 			v.AddRule(field, validator)
 		}
@@ -216,6 +372,20 @@ func (v *Validation) StringRule(field, rule string, filterRule ...string) *Valid
 	return v
 }
 
+// ruleArgs parse the rule args string for the validator.
+func ruleArgs(realName, argStr string) []any {
+	switch realName {
+	// eg 'regex:\d{4,6}' dont need split args. args is "\d{4,6}"
+	case RuleRegexp, RuleEvalExpr:
+		return []any{argStr}
+	// some special validator. need merge args to one.
+	// "rule_one_of" (#292) also收集为单个 []string 列表参数, 子项为校验器名。
+	case "enum", "notIn", "rule_one_of":
+		return []any{parseArgString(argStr)}
+	}
+	return strings2Args(parseArgString(argStr))
+}
+
 // StringRules add multi rules by string map.
 //
 // Usage:
@@ -0,0 +1,350 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/validate/v2/internal/fieldval"
+)
+
+// dive rule segment names
+const (
+	// RuleDive apply the following rules to each element of a slice, array or map.
+	// eg: "required|dive|in:a,b"
+	RuleDive = "dive"
+	// RuleKeys start the map key rules after "dive", end by RuleEndKeys.
+	// eg: "dive|keys|alpha|endkeys|required"
+	RuleKeys = "keys"
+	// RuleEndKeys end the map key rules.
+	RuleEndKeys = "endkeys"
+)
+
+// diveRules is the compiled rules after a "dive" segment. It is built once when
+// the rule string is parsed and is read-only afterwards, same as ruleExpr.
+//
+// Format:
+//
+//	dive [keys {key rule} endkeys] {elem rule} [dive ...]
+//
+// eg:
+//
+//	"dive|in:a,b"                                 // []string
+//	"dive|required|dive|email"                    // [][]string
+//	"dive|keys|alpha|endkeys|required|dive|email" // map[string][]string
+type diveRules struct {
+	// rules for each map key. only for map value
+	keys []*Rule
+	// rules for each element value
+	elems []*Rule
+	// next level dive, apply to each element value
+	next *diveRules
+}
+
+// String returns the normalized rules text after "dive"
+func (d *diveRules) String() string {
+	var ss []string
+	if len(d.keys) > 0 {
+		ss = append(ss, RuleKeys)
+		for _, r := range d.keys {
+			ss = append(ss, inlineRuleString(r))
+		}
+		ss = append(ss, RuleEndKeys)
+	}
+
+	for _, r := range d.elems {
+		ss = append(ss, inlineRuleString(r))
+	}
+	if d.next != nil {
+		ss = append(ss, RuleDive)
+		if s := d.next.String(); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return strings.Join(ss, "|")
+}
+
+func inlineRuleString(r *Rule) string {
+	if r.realName == RuleExprName {
+		return r.arguments[0].(*ruleExpr).String()
+	}
+	if len(r.arguments) == 0 {
+		return r.validator
+	}
+	return r.validator + ":" + strings.Join(args2strings(r.arguments), ",")
+}
+
+// parseDive compile the rule segments after a "dive" segment.
+func parseDive(segs []string) (*diveRules, error) {
+	d := &diveRules{}
+
+	i := 0
+	if i < len(segs) && strings.TrimSpace(segs[i]) == RuleKeys {
+		for i++; ; i++ {
+			if i == len(segs) {
+				return nil, diveErrorf(segs, "missing '%s' for '%s'", RuleEndKeys, RuleKeys)
+			}
+
+			seg := strings.Trim(strings.TrimSpace(segs[i]), ":")
+			if seg == RuleEndKeys {
+				i++
+				break
+			}
+			if seg == "" {
+				continue
+			}
+
+			r, err := compileInlineRule(seg)
+			if err != nil {
+				return nil, diveErrorf(segs, "%s", err.Error())
+			}
+			d.keys = append(d.keys, r)
+		}
+	}
+
+	for ; i < len(segs); i++ {
+		seg := strings.Trim(strings.TrimSpace(segs[i]), ":")
+		switch seg {
+		case "":
+			continue
+		case RuleDive:
+			next, err := parseDive(segs[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			d.next = next
+			return d, nil
+		case RuleKeys, RuleEndKeys:
+			return nil, diveErrorf(segs, "'%s' must follow right after '%s'", seg, RuleDive)
+		}
+
+		r, err := compileInlineRule(seg)
+		if err != nil {
+			return nil, diveErrorf(segs, "%s", err.Error())
+		}
+		d.elems = append(d.elems, r)
+	}
+	return d, nil
+}
+
+// mustParseDive compile the rule segments after "dive", panic on error.
+func mustParseDive(segs []string) *diveRules {
+	d, err := parseDive(segs)
+	if err != nil {
+		panic("validate: " + err.Error())
+	}
+	return d
+}
+
+func diveErrorf(segs []string, format string, args ...any) error {
+	return fmt.Errorf("invalid dive rules '%s': %s", strings.Join(segs, "|"), fmt.Sprintf(format, args...))
+}
+
+// compileInlineRule compile one rule segment to an inline rule. the segment
+// can be a boolean expression. eg: "in:a,b", "email or isCnMobile"
+func compileInlineRule(seg string) (*Rule, error) {
+	if isRuleExpr(seg) {
+		e, err := parseRuleExpr(seg)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Rule{
+			validator:       RuleExprName,
+			realName:        RuleExprName,
+			arguments:       []any{e},
+			argsReady:       true,
+			nameNotRequired: true,
+		}, nil
+	}
+
+	name, argStr, hasArgs := strings.Cut(seg, ":")
+	return newInlineRule(name, argStr, hasArgs)
+}
+
+// diveRulesOf get the compiled dive rules from the rule arguments.
+// returns nil on the bad dive rules, see Validation.configErrorf
+func (r *Rule) diveRulesOf(v *Validation) *diveRules {
+	if len(r.arguments) == 0 {
+		return &diveRules{}
+	}
+
+	field := strings.Join(r.fields, ",")
+	switch arg := r.arguments[0].(type) {
+	case *diveRules:
+		return arg
+	case string: // add by AddRule(field, "dive", "in:a,b")
+		d, err := parseDive(splitRules(arg))
+		if err != nil {
+			v.configErrorf(field, RuleDive, "%s", err.Error())
+		}
+		return d
+	}
+
+	v.configErrorf(field, RuleDive, "the validator '%s' requires a rules string argument", RuleDive)
+	return nil
+}
+
+// diveValidate apply the dive rules to each element of the field value.
+// Each failed element is reported with its indexed path. eg: "tags.1",
+// "labels.en", "matrix.0.2"
+func (r *Rule) diveValidate(field string, fv *fieldval.FieldValue, v *Validation) bool {
+	d := r.diveRulesOf(v)
+	if d == nil {
+		return true
+	}
+	return r.diveInto(d, field, field, fv.RV(), v)
+}
+
+// diveInto validate each element of rv by the dive rules d.
+//
+//   - field: the rule field name, used to find error messages. eg: "tags"
+//   - path: the value path. eg: "tags", "matrix.1"
+func (r *Rule) diveInto(d *diveRules, field, path string, rv reflect.Value, v *Validation) (ok bool) {
+	rv = indirectInterface(rv)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = indirectInterface(rv.Elem())
+	}
+
+	ok = true
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if len(d.keys) > 0 {
+			v.configErrorf(field, RuleDive, "the '%s' rules can only be used to dive into a map, field '%s'", RuleKeys, field)
+			return
+		}
+
+		eachElem(rv, func(i int, ev reflect.Value) bool {
+			if r.diveElem(d, field, path+"."+strconv.Itoa(i), ev, v) {
+				return true
+			}
+			ok = false
+			return !v.shouldStop()
+		})
+	case reflect.Map:
+		for _, key := range sortedMapKeys(rv) {
+			elemPath := path + "." + fmt.Sprint(key.Interface())
+			if !r.checkElem(d.keys, field, elemPath, key, v) {
+				ok = false
+				if v.shouldStop() {
+					return
+				}
+				continue
+			}
+
+			if !r.diveElem(d, field, elemPath, rv.MapIndex(key), v) {
+				ok = false
+				if v.shouldStop() {
+					return
+				}
+			}
+		}
+	default:
+		// nil/empty value is checked by the element rules of parent level
+		if !rv.IsValid() || rv.Kind() == reflect.Ptr {
+			return
+		}
+
+		v.addFieldError(FieldError{
+			Field:   path,
+			Name:    elemErrKey(field, path, v),
+			Label:   elemLabel(field, path, v),
+			Alias:   RuleDive,
+			Value:   rv.Interface(),
+			Message: r.errorMessageAt(field, path, RuleDive, nil, v),
+		})
+		return false
+	}
+	return
+}
+
+// diveElem validate one element by the element rules, then dive into it.
+func (r *Rule) diveElem(d *diveRules, field, path string, ev reflect.Value, v *Validation) bool {
+	if !r.checkElem(d.elems, field, path, ev, v) {
+		return false
+	}
+
+	if d.next != nil {
+		return r.diveInto(d.next, field, path, ev, v)
+	}
+	return true
+}
+
+// checkElem validate an element value or map key by the inline rules.
+// stop at the first failed rule, like the rules of a field.
+func (r *Rule) checkElem(rules []*Rule, field, path string, ev reflect.Value, v *Validation) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	ev = indirectInterface(ev)
+
+	var fv *fieldval.FieldValue
+	if ev.IsValid() && ev.CanInterface() {
+		fv = fieldval.NewRV(path, ev)
+	} else {
+		fv = fieldval.New(path, nil)
+	}
+
+	for _, er := range rules {
+		// empty element AND is not required* AND skip on empty.
+		if r.skipEmpty && er.nameNotRequired && fv.IsEmpty() {
+			continue
+		}
+
+		if !er.inlineValidate(path, fv, v) {
+			msg := r.errorMessageAt(field, path, er.validator, er.arguments, v)
+			if v.ErrShowValue {
+				msg = fmt.Sprintf("%s (value: %v)", msg, fv.Src())
+			}
+			v.addFieldError(FieldError{
+				Field:     path,
+				Name:      elemErrKey(field, path, v),
+				Label:     elemLabel(field, path, v),
+				Validator: er.realName,
+				Alias:     er.validator,
+				Args:      er.errorArgs(),
+				Value:     fv.Src(),
+				Message:   msg,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// elemErrKey build the error key of the element path, keep the same prefix
+// as the field error key. eg: "Tags.1" -> "tags.1"
+func elemErrKey(field, path string, v *Validation) string {
+	return v.trans.FieldName(field) + strings.TrimPrefix(path, field)
+}
+
+// elemLabel build the display name of the element path. eg: "Tags.1"
+func elemLabel(field, path string, v *Validation) string {
+	return v.trans.LabelName(field) + strings.TrimPrefix(path, field)
+}
+
+// sortedMapKeys returns the map keys in a stable order, so the errors order
+// is deterministic.
+func sortedMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := indirectInterface(keys[i]), indirectInterface(keys[j])
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.String:
+				return a.String() < b.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			}
+		}
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
@@ -0,0 +1,148 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestParseDive(t *testing.T) {
+	is := assert.New(t)
+
+	d, err := parseDive(splitRules("keys|alpha|endkeys|required|dive|email or isCnMobile"))
+	is.NoErr(err)
+	is.Len(d.keys, 1)
+	is.Len(d.elems, 1)
+	is.NotNil(d.next)
+	is.Len(d.next.elems, 1)
+	is.Eq(RuleExprName, d.next.elems[0].realName)
+	is.Eq("keys|alpha|endkeys|required|dive|email or isCnMobile", d.String())
+
+	// typed args are pre-converted for builtin validators
+	d, err = parseDive([]string{"len:2"})
+	is.NoErr(err)
+	is.True(d.elems[0].argsReady)
+	is.Eq(2, d.elems[0].arguments[0])
+
+	for _, bad := range []string{"keys|alpha", "required|keys|alpha|endkeys", "endkeys", "default:1", "optional"} {
+		_, err = parseDive(splitRules(bad))
+		is.Err(err, bad)
+	}
+
+	is.PanicsMsg(func() {
+		NewEmpty().StringRule("tags", "dive|keys|alpha")
+	}, "validate: invalid dive rules 'keys|alpha': missing 'endkeys' for 'keys'")
+}
+
+func TestValidation_StringRule_dive(t *testing.T) {
+	is := assert.New(t)
+
+	// issue #266: "in" compares each element, not the whole slice
+	v := Map(M{"tags": []string{"go", "php"}})
+	v.StringRule("tags", "required|dive|in:go,php,java")
+	is.True(v.Validate())
+
+	v = Map(M{"tags": []string{"go", "rust", "c"}})
+	v.StopOnError = false
+	v.StringRule("tags", "required|dive|in:go,php,java")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Eq("tags.1 value must be in the enum [go php java]", v.Errors.FieldOne("tags.1"))
+	is.True(v.Errors.HasField("tags.2"))
+
+	// stop on first error
+	v = Map(M{"tags": []string{"go", "rust", "c"}})
+	v.StopOnError = true
+	v.StringRule("tags", "dive|in:go,php")
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+
+	// nested slice
+	v = Map(M{"matrix": [][]int{{1, 2}, {3, -1, 5}}})
+	v.StringRule("matrix", "dive|required|dive|int|min:1")
+	is.False(v.Validate())
+	is.Eq("matrix.1.1 min value is 1", v.Errors.One())
+
+	// map keys and values
+	v = Map(M{"labels": map[string]string{"en": "Name", "zh-CN": "名称", "ja": ""}})
+	v.StopOnError = false
+	v.StringRule("labels", "dive|keys|alpha|endkeys|required")
+	is.False(v.Validate())
+	is.Len(v.Errors, 2)
+	is.Eq("labels.ja is required to not be empty", v.Errors.FieldOne("labels.ja"))
+	is.Eq("labels.zh-CN value contains only alpha char", v.Errors.FieldOne("labels.zh-CN"))
+
+	// not a list value
+	v = Map(M{"tags": "go"})
+	v.StringRule("tags", "dive|in:go")
+	is.False(v.Validate())
+	is.Eq("tags value must be an array, slice or map", v.Errors.One())
+
+	// empty value is skipped, element rules are not applied
+	v = Map(M{"tags": []string{}})
+	v.StringRule("tags", "dive|required")
+	is.True(v.Validate())
+
+	// custom messages and labels by the field name
+	v = Map(M{"tags": []string{"go", "rust"}})
+	v.StringRule("tags", "dive|in:go,php")
+	v.AddTranslates(MS{"tags": "Tags"})
+	v.AddMessages(MS{"tags.in": "{field} is not a supported language"})
+	is.False(v.Validate())
+	is.Eq("Tags.1 is not a supported language", v.Errors.One())
+
+	// programmatic rule
+	v = Map(M{"emails": []any{"tom@example.com", "abc"}})
+	v.AddRule("emails", RuleDive, "email")
+	is.False(v.Validate())
+	is.True(v.Errors.HasField("emails.1"))
+}
+
+type diveItem struct {
+	Name string `validate:"required"`
+}
+
+type diveUser struct {
+	Tags   []string              `json:"tags" validate:"required|dive|in:go,php,java" message:"tag {field} is invalid"`
+	Matrix [][]string            `json:"matrix" validate:"dive|dive|minLen:2"`
+	Labels map[string]string     `json:"labels" validate:"dive|keys|alpha|endkeys|required"`
+	Groups map[string][]diveItem `json:"groups" validate:"dive|keys|alpha|minLen:3|endkeys|required"`
+}
+
+func TestStruct_dive(t *testing.T) {
+	is := assert.New(t)
+
+	u := &diveUser{
+		Tags:   []string{"go", "php"},
+		Matrix: [][]string{{"ab", "cd"}},
+		Labels: map[string]string{"en": "Name"},
+		Groups: map[string][]diveItem{"admin": {{Name: "tom"}}},
+	}
+	is.NoErr(CheckErr(u))
+
+	u.Tags = []string{"go", "rust"}
+	r := Check(u)
+	is.True(r.Fail())
+	is.Eq("tag tags.1 is invalid", r.Errors.FieldOne("tags.1"))
+
+	u.Tags = []string{"go"}
+	u.Matrix = [][]string{{"ab"}, {"cd", "e"}}
+	u.Labels = map[string]string{"en1": "Name"}
+	u.Groups = map[string][]diveItem{"ad": {{Name: "tom"}}, "dev": nil}
+	v := Struct(u)
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.True(v.Errors.HasField("matrix.1.1"))
+	is.True(v.Errors.HasField("labels.en1"))
+	is.True(v.Errors.HasField("groups.ad"))
+	is.True(v.Errors.HasField("groups.dev"))
+}
+
+func TestVal_dive(t *testing.T) {
+	is := assert.New(t)
+
+	is.NoErr(Val([]string{"tom@example.com"}, "required|dive|email"))
+	err := Val([]string{"tom@example.com", "abc"}, "dive|email")
+	is.Err(err)
+	is.Contains(err.Error(), "input.1 value is an invalid email address")
+}
@@ -0,0 +1,355 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gookit/validate/v2/internal/fieldval"
+)
+
+// RuleExprName is the validator name of a boolean rule expression. A rule
+// segment like "(email or isCnMobile)" is compiled into one rule with this
+// validator, so custom messages can target it as "field.rule_expr".
+const RuleExprName = "rule_expr"
+
+// expr node kinds
+const (
+	exprLeaf uint8 = iota
+	exprAnd
+	exprOr
+	exprNot
+)
+
+// ruleExpr is a compiled boolean rule expression. It is built once when the
+// rule string is parsed and is read-only afterwards, so it is safe to share
+// across Validation instances (eg: by the static struct rule template).
+//
+// Grammar:
+//
+//	expr  := and { "or" and }
+//	and   := unary { "and" unary }
+//	unary := ("not" | "!") unary | "(" expr ")" | leaf
+//	leaf  := validator [":" args]
+//
+// NOTE: "|" still separates the top-level rule segments, so it is not an
+// operator inside an expression. escape it as "\|" in leaf args.
+type ruleExpr struct {
+	kind uint8
+	// sub nodes for and/or/not
+	subs []*ruleExpr
+	// leaf validator rule. only for exprLeaf
+	rule *Rule
+	// leaf source text. eg: "len:11"
+	text string
+}
+
+// String returns the normalized expression text, used in error messages.
+func (e *ruleExpr) String() string {
+	switch e.kind {
+	case exprNot:
+		return "not " + e.subs[0].wrapString(exprNot)
+	case exprAnd, exprOr:
+		sep := " and "
+		if e.kind == exprOr {
+			sep = " or "
+		}
+
+		ss := make([]string, len(e.subs))
+		for i, sub := range e.subs {
+			ss[i] = sub.wrapString(e.kind)
+		}
+		return strings.Join(ss, sep)
+	}
+	return e.text
+}
+
+// wrapString renders the node, adding parentheses when it is a binary node
+// nested inside a node of a different kind.
+func (e *ruleExpr) wrapString(parent uint8) string {
+	if (e.kind == exprAnd || e.kind == exprOr) && e.kind != parent {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// eval the expression on the field value. and/or are short-circuited.
+func (e *ruleExpr) eval(field string, fv *fieldval.FieldValue, v *Validation) bool {
+	switch e.kind {
+	case exprAnd:
+		for _, sub := range e.subs {
+			if !sub.eval(field, fv, v) {
+				return false
+			}
+		}
+		return true
+	case exprOr:
+		for _, sub := range e.subs {
+			if sub.eval(field, fv, v) {
+				return true
+			}
+		}
+		return false
+	case exprNot:
+		return !e.subs[0].eval(field, fv, v)
+	}
+
+	return e.rule.inlineValidate(field, fv, v)
+}
+
+// isRuleExpr check the rule segment is a boolean expression.
+//
+// eg: "(email or isCnMobile)", "not contains:admin", "email or isCnMobile"
+func isRuleExpr(s string) bool {
+	if s == "" {
+		return false
+	}
+	if s[0] == '(' || s[0] == '!' {
+		return true
+	}
+
+	// regexp and default args are raw text, never treat them as an expression.
+	name := s
+	if pos := strings.IndexAny(s, ": \t"); pos > 0 {
+		name = s[:pos]
+	}
+	if name == "not" || name == "NOT" {
+		return len(s) > len(name)
+	}
+
+	realName := ValidatorName(name)
+	if realName == RuleRegexp || realName == RuleDefault || realName == RuleEvalExpr {
+		return false
+	}
+
+	return containsWord(s, "or") || containsWord(s, "and") ||
+		containsWord(s, "OR") || containsWord(s, "AND")
+}
+
+// containsWord check s contains the word, surrounded by whitespace.
+func containsWord(s, word string) bool {
+	for _, f := range strings.Fields(s) {
+		if f == word {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRuleExpr compile a boolean rule expression string.
+func parseRuleExpr(s string) (*ruleExpr, error) {
+	p := &exprParser{toks: tokenizeRuleExpr(s), src: s}
+	if len(p.toks) == 0 {
+		return nil, p.errorf("empty expression")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, p.errorf("unexpected token %q", p.toks[p.pos])
+	}
+	return e, nil
+}
+
+// mustParseRuleExpr compile a boolean rule expression string, panic on error.
+func mustParseRuleExpr(s string) *ruleExpr {
+	e, err := parseRuleExpr(s)
+	if err != nil {
+		panic("validate: " + err.Error())
+	}
+	return e
+}
+
+// tokenizeRuleExpr split the expression into tokens: "(", ")", "!" and words.
+// A word ends at whitespace or an unmatched ")", so leaf args may contain
+// balanced parentheses. eg: "regexp:^(a\|b)$"
+func tokenizeRuleExpr(s string) (toks []string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')' || c == '!':
+			toks = append(toks, s[i:i+1])
+			i++
+		default:
+			start, depth := i, 0
+			for ; i < len(s); i++ {
+				c = s[i]
+				if unicode.IsSpace(rune(c)) {
+					break
+				}
+				if c == '(' {
+					depth++
+				} else if c == ')' {
+					if depth == 0 {
+						break
+					}
+					depth--
+				}
+			}
+			toks = append(toks, s[start:i])
+		}
+	}
+	return
+}
+
+type exprParser struct {
+	src  string
+	toks []string
+	pos  int
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return &RuleExprError{Expr: p.src, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) parseOr() (*ruleExpr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek() {
+		case "or", "OR":
+			p.pos++
+			sub, err := p.parseAnd()
+			if err != nil {
+				return nil, err
+			}
+			e = joinExpr(exprOr, e, sub)
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *exprParser) parseAnd() (*ruleExpr, error) {
+	e, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek() {
+		case "and", "AND":
+			p.pos++
+			sub, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			e = joinExpr(exprAnd, e, sub)
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (*ruleExpr, error) {
+	tok := p.peek()
+	switch tok {
+	case "":
+		return nil, p.errorf("unexpected end of expression")
+	case "not", "NOT", "!":
+		p.pos++
+		sub, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleExpr{kind: exprNot, subs: []*ruleExpr{sub}}, nil
+	case "(":
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return e, nil
+	case ")", "and", "AND", "or", "OR":
+		return nil, p.errorf("unexpected token %q", tok)
+	}
+
+	p.pos++
+	return newExprLeaf(tok)
+}
+
+// joinExpr flatten same kind binary nodes. eg: "a or b or c" -> or(a, b, c)
+func joinExpr(kind uint8, left, right *ruleExpr) *ruleExpr {
+	e := left
+	if left.kind != kind {
+		e = &ruleExpr{kind: kind, subs: []*ruleExpr{left}}
+	}
+
+	if right.kind == kind {
+		e.subs = append(e.subs, right.subs...)
+	} else {
+		e.subs = append(e.subs, right)
+	}
+	return e
+}
+
+// newExprLeaf build a leaf validator rule from text like "len:11"
+func newExprLeaf(text string) (*ruleExpr, error) {
+	name, argStr, hasArgs := strings.Cut(text, ":")
+	if name == "" {
+		return nil, &RuleExprError{Expr: text, Msg: "missing validator name"}
+	}
+
+	r, err := newInlineRule(name, argStr, hasArgs)
+	if err != nil {
+		return nil, &RuleExprError{Expr: text, Msg: err.Error()}
+	}
+	r.inExpr = true
+	return &ruleExpr{kind: exprLeaf, rule: r, text: text}, nil
+}
+
+// RuleExprError is returned on parse a bad rule expression.
+type RuleExprError struct {
+	// Expr the expression text
+	Expr string
+	// Msg the error message
+	Msg string
+}
+
+// Error string
+func (e *RuleExprError) Error() string {
+	return "invalid rule expression '" + e.Expr + "': " + e.Msg
+}
+
+// RuleExpr validator: val must satisfy the compiled boolean rule expression.
+// It is bound as the "rule_expr" validator, expr is a value parsed from the
+// rule string. see StringRule.
+func (v *Validation) RuleExpr(val any, expr any) bool {
+	return v.evalRuleExpr("", fieldval.New("", val), expr)
+}
+
+// evalRuleExpr evaluate the expression on the field value carrier.
+func (v *Validation) evalRuleExpr(field string, fv *fieldval.FieldValue, expr any) bool {
+	switch e := expr.(type) {
+	case *ruleExpr:
+		return e.eval(field, fv, v)
+	case string:
+		pe, err := parseRuleExpr(e)
+		if err != nil {
+			v.configErrorf(field, RuleExprName, "%s", err.Error())
+			return true
+		}
+		return pe.eval(field, fv, v)
+	}
+
+	v.configErrorf(field, RuleExprName, "the validator '%s' requires a rule expression argument", RuleExprName)
+	return true
+}
@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestParseRuleExpr(t *testing.T) {
+	is := assert.New(t)
+
+	e, err := parseRuleExpr("email or (isCnMobile and len:11)")
+	is.NoErr(err)
+	is.Eq(exprOr, e.kind)
+	is.Len(e.subs, 2)
+	is.Eq("email or (isCnMobile and len:11)", e.String())
+
+	// same kind nodes are flattened
+	e, err = parseRuleExpr("int or (uint or float)")
+	is.NoErr(err)
+	is.Len(e.subs, 3)
+	is.Eq("int or uint or float", e.String())
+
+	e, err = parseRuleExpr("!contains:admin")
+	is.NoErr(err)
+	is.Eq(exprNot, e.kind)
+	is.Eq("not contains:admin", e.String())
+
+	// leaf args with balanced parentheses
+	e, err = parseRuleExpr(`not (regexp:^(ab)+$ or minLen:3)`)
+	is.NoErr(err)
+	is.Eq("not (regexp:^(ab)+$ or minLen:3)", e.String())
+	is.Eq("^(ab)+$", e.subs[0].subs[0].rule.arguments[0])
+
+	// typed args are pre-converted for builtin validators
+	e, err = parseRuleExpr("len:11 and min:1")
+	is.NoErr(err)
+	is.True(e.subs[0].rule.argsReady)
+	is.Eq(11, e.subs[0].rule.arguments[0])
+
+	for _, bad := range []string{"", "(email", "email or", "and email", "email)", "default:1 or int"} {
+		_, err = parseRuleExpr(bad)
+		is.Err(err, bad)
+	}
+
+	is.PanicsMsg(func() {
+		mustParseRuleExpr("(email or int")
+	}, "validate: invalid rule expression '(email or int': missing closing parenthesis")
+}
+
+func TestIsRuleExpr(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(isRuleExpr("(email)"))
+	is.True(isRuleExpr("!email"))
+	is.True(isRuleExpr("not contains:admin"))
+	is.True(isRuleExpr("email or isCnMobile"))
+	is.True(isRuleExpr("int AND min:1"))
+
+	is.False(isRuleExpr(""))
+	is.False(isRuleExpr("required"))
+	is.False(isRuleExpr("contains:android"))
+	is.False(isRuleExpr("not_in:a,b"))
+	is.False(isRuleExpr("regexp:^a or b$"))
+	is.False(isRuleExpr("default:tom and jerry"))
+}
+
+func TestValidation_StringRule_expr(t *testing.T) {
+	is := assert.New(t)
+	rule := "required|(email or (isCnMobile and len:11))|not contains:admin"
+
+	tests := []struct {
+		val string
+		ok  bool
+	}{
+		{"tom@example.com", true},
+		{"13812345678", true},
+		{"admin@example.com", false},
+		{"1381234567", false},
+		{"not-a-contact", false},
+	}
+
+	for _, tt := range tests {
+		v := Map(M{"contact": tt.val})
+		v.StringRule("contact", rule)
+		is.Eq(tt.ok, v.Validate(), tt.val)
+	}
+
+	// error message and key
+	v := Map(M{"contact": "abc"})
+	v.StringRule("contact", rule)
+	is.False(v.Validate())
+	is.True(v.Errors.HasField("contact"))
+	is.Eq(
+		"contact did not satisfy the rule: email or (isCnMobile and len:11)",
+		v.Errors.FieldOne("contact"),
+	)
+
+	// custom message
+	v = Map(M{"contact": "abc"})
+	v.StringRule("contact", rule)
+	v.AddMessages(MS{"contact.rule_expr": "contact must be an email or a phone number"})
+	is.False(v.Validate())
+	is.Eq("contact must be an email or a phone number", v.Errors.One())
+
+	// empty value is skipped like other non-required validators
+	v = Map(M{"contact": ""})
+	v.StringRule("contact", "email or isCnMobile")
+	is.True(v.Validate())
+
+	// context validators can be used in an expression
+	v = Map(M{"a": 2, "b": 3})
+	v.StringRule("a", "ltField:b or eqField:b")
+	is.True(v.Validate())
+}
+
+type ruleExprUser struct {
+	Contact string `json:"contact" validate:"required|(email or isCnMobile)|!contains:admin" message:"contact is invalid"`
+	Age     int    `json:"age" validate:"int and (min:18 or eq:0)"`
+}
+
+func TestStruct_ruleExpr(t *testing.T) {
+	is := assert.New(t)
+
+	u := &ruleExprUser{Contact: "tom@example.com", Age: 20}
+	is.NoErr(CheckErr(u))
+
+	u = &ruleExprUser{Contact: "13812345678", Age: 16}
+	err := CheckErr(u)
+	is.Err(err)
+	is.Eq("age did not satisfy the rule: int and (min:18 or eq:0)", err.Error())
+
+	// field-level message tag is applied to the expression rule
+	u = &ruleExprUser{Contact: "admin@example.com", Age: 20}
+	r := Check(u)
+	is.True(r.Fail())
+	is.Eq("contact is invalid", r.Errors.One())
+}
+
+func TestVal_ruleExpr(t *testing.T) {
+	is := assert.New(t)
+
+	is.NoErr(Val("tom@example.com", "required|email or isCnMobile"))
+	is.NoErr(Val("13812345678", "email or isCnMobile"))
+	is.Err(Val("abc", "email or isCnMobile"))
+	is.Err(Val("admin", "not contains:adm"))
+}
@@ -0,0 +1,312 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// RuleFile is the declarative config of the field rules, filters, messages,
+// labels, default values and scenes. see LoadRuleFile
+//
+// JSON format:
+//
+//	{
+//		"scenes": {"create": ["name", "email"]},
+//		"messages": {"required": "{field} is required"},
+//		"fields": {
+//			"name": {
+//				"rules": "required|minLen:2",
+//				"filters": "trim|lower",
+//				"messages": "required:name is required|minLen:name is too short",
+//				"label": "User Name",
+//				"default": "guest",
+//				"scenes": ["update"]
+//			}
+//		}
+//	}
+type RuleFile struct {
+	// Name the file name for the diagnostics.
+	Name string `json:"-" yaml:"-"`
+	// Scenes the scenes config. {scene: [field, ...]}
+	Scenes SValues `json:"scenes,omitempty"`
+	// Messages the validator messages, same as WithMessages.
+	Messages MS `json:"messages,omitempty"`
+	// Fields the field configs, the key is the field name.
+	Fields map[string]*RuleFileField `json:"fields"`
+	// Unknown the unknown keys and the line number, set by the decoder.
+	// They are reported as the config errors. {key: line}
+	Unknown map[string]int `json:"-" yaml:"-"`
+}
+
+// RuleFileField the config of a field in the RuleFile.
+type RuleFileField struct {
+	// Rules the validator rules, same as StringRule. eg: "required|minLen:2"
+	Rules string `json:"rules,omitempty"`
+	// Filters the filter rules, same as FilterRule. eg: "trim|lower"
+	Filters string `json:"filters,omitempty"`
+	// Messages the error messages, same as StringMessage.
+	Messages string `json:"messages,omitempty"`
+	// Label the field translate name in the messages.
+	Label string `json:"label,omitempty"`
+	// Default the default value of the field.
+	Default any `json:"default,omitempty"`
+	// Scenes the scene names the field is added to.
+	Scenes []string `json:"scenes,omitempty"`
+	// Line the line number of the field in the file, 0 if unknown.
+	Line int `json:"-" yaml:"-"`
+	// Lines the line number of the keys, the key is lower case. eg: {"rules": 3}
+	Lines map[string]int `json:"-" yaml:"-"`
+	// Unknown the unknown keys and the line number, set by the decoder.
+	// They are reported as the config errors. {key: line}
+	Unknown map[string]int `json:"-" yaml:"-"`
+}
+
+// lineOf get the line number of the key, fallback to the field line.
+func (f *RuleFileField) lineOf(key string) int {
+	if line, ok := f.Lines[key]; ok {
+		return line
+	}
+	return f.Line
+}
+
+// RuleFileDecoder decode the rule file contents to the RuleFile. The decoder
+// can set the Line, Lines and Unknown for reporting the errors position.
+//
+// eg: use YAML by gopkg.in/yaml.v3, the keys are same as the JSON format.
+//
+//	rs, err := validate.LoadRuleFile(file, func(bs []byte, rf *validate.RuleFile) error {
+//		return yaml.Unmarshal(bs, rf)
+//	})
+type RuleFileDecoder func(bs []byte, rf *RuleFile) error
+
+// LoadRuleFile read the rule file from r, and compile it to a RuleSet. The
+// default format is JSON, can use the decoder for the other formats.
+//
+// The bad rules are returned as the ConfigErrors, with the file name and line
+// number. eg: "rules.json:5: field 'name': the validator 'minLn' does not exist"
+//
+// Usage:
+//
+//	file, err := os.Open("rules/user.json")
+//	rs, err := validate.LoadRuleFile(file)
+//	res := rs.Validate(r) // r is the *http.Request
+func LoadRuleFile(r io.Reader, decoder ...RuleFileDecoder) (*RuleSet, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rf := &RuleFile{}
+	if nf, ok := r.(interface{ Name() string }); ok {
+		rf.Name = nf.Name()
+	}
+
+	if len(decoder) > 0 {
+		err = decoder[0](bs, rf)
+	} else {
+		err = decodeJSONRuleFile(bs, rf)
+	}
+	if err != nil {
+		if rf.Name != "" {
+			return nil, fmt.Errorf("%w: decode the rule file %s: %w", ErrInvalidData, rf.Name, err)
+		}
+		return nil, fmt.Errorf("%w: decode the rule file: %w", ErrInvalidData, err)
+	}
+	return RuleSetFromFile(rf)
+}
+
+// RuleSetFromFile compile the RuleFile to a RuleSet. The fields are added by
+// the line number, then the name.
+func RuleSetFromFile(rf *RuleFile) (*RuleSet, error) {
+	names := make([]string, 0, len(rf.Fields))
+	for name := range rf.Fields {
+		names = append(names, name)
+	}
+	lineOf := func(name string) int {
+		if f := rf.Fields[name]; f != nil {
+			return f.Line
+		}
+		return 0
+	}
+	sort.Slice(names, func(i, j int) bool {
+		li, lj := lineOf(names[i]), lineOf(names[j])
+		if li != lj {
+			return li < lj
+		}
+		return names[i] < names[j]
+	})
+
+	return CompileRuleSet(func(v *Validation) {
+		// set the position of the new config errors added by fn.
+		at := func(line int, fn func()) {
+			n := len(v.configErrs)
+			fn()
+			for _, e := range v.configErrs[n:] {
+				e.File, e.Line = rf.Name, line
+			}
+		}
+
+		for _, key := range sortedKeys(rf.Unknown) {
+			at(rf.Unknown[key], func() {
+				v.configErrorf("", key, "unknown rule file key '%s'", key)
+			})
+		}
+		if len(rf.Messages) > 0 {
+			v.WithMessages(rf.Messages)
+		}
+
+		scenes := make(SValues, len(rf.Scenes))
+		for scene, fields := range rf.Scenes {
+			scenes[scene] = append([]string(nil), fields...)
+		}
+
+		for _, name := range names {
+			f := rf.Fields[name]
+			if f == nil {
+				continue
+			}
+
+			for _, key := range sortedKeys(f.Unknown) {
+				at(f.Unknown[key], func() {
+					v.configErrorf(name, key, "unknown rule file key '%s'", key)
+				})
+			}
+
+			if f.Rules != "" {
+				at(f.lineOf("rules"), func() {
+					n := len(v.rules)
+					v.StringRule(name, f.Rules)
+					for _, r := range v.rules[n:] {
+						r.precompile(v)
+					}
+				})
+			}
+			if f.Filters != "" {
+				at(f.lineOf("filters"), func() {
+					v.FilterRule(name, f.Filters).precompile(v)
+				})
+			}
+
+			v.StringMessage(name, f.Messages)
+			if f.Label != "" {
+				v.AddTranslates(map[string]string{name: f.Label})
+			}
+			if f.Default != nil {
+				v.SetDefValue(name, f.Default)
+			}
+			for _, scene := range f.Scenes {
+				if !slices.Contains(scenes[scene], name) {
+					scenes[scene] = append(scenes[scene], name)
+				}
+			}
+		}
+
+		if len(scenes) > 0 {
+			v.WithScenes(scenes)
+		}
+	})
+}
+
+// decodeJSONRuleFile decode the JSON rule file, and collect the line numbers
+// and the unknown keys of the file and fields.
+func decodeJSONRuleFile(bs []byte, rf *RuleFile) error {
+	if err := json.Unmarshal(bs, rf); err != nil {
+		var se *json.SyntaxError
+		var te *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &se):
+			return fmt.Errorf("line %d: %w", lineAt(bs, se.Offset), err)
+		case errors.As(err, &te):
+			return fmt.Errorf("line %d: %w", lineAt(bs, te.Offset), err)
+		}
+		return err
+	}
+
+	lines := &jsonLines{dec: json.NewDecoder(bytes.NewReader(bs)), bs: bs}
+	lines.object(func(key string, line int) {
+		switch strings.ToLower(key) {
+		case "fields":
+			lines.object(func(name string, line int) {
+				f := rf.Fields[name]
+				if f == nil { // null
+					lines.skip()
+					return
+				}
+
+				f.Line = line
+				f.Lines = make(map[string]int)
+				lines.object(func(key string, line int) {
+					// the keys are case-insensitive, same as json.Unmarshal
+					switch lower := strings.ToLower(key); lower {
+					case "rules", "filters", "messages", "label", "default", "scenes":
+						f.Lines[lower] = line
+					default:
+						if f.Unknown == nil {
+							f.Unknown = make(map[string]int)
+						}
+						f.Unknown[key] = line
+					}
+					lines.skip()
+				})
+			})
+		case "scenes", "messages":
+			lines.skip()
+		default:
+			if rf.Unknown == nil {
+				rf.Unknown = make(map[string]int)
+			}
+			rf.Unknown[key] = line
+			lines.skip()
+		}
+	})
+	return nil
+}
+
+// jsonLines walk the valid JSON object keys with the line number.
+type jsonLines struct {
+	dec *json.Decoder
+	bs  []byte
+}
+
+// object walk the keys of the object value, fn must read the value.
+func (l *jsonLines) object(fn func(key string, line int)) {
+	if tok, _ := l.dec.Token(); tok != json.Delim('{') {
+		return // null
+	}
+
+	for l.dec.More() {
+		tok, _ := l.dec.Token()
+		key, _ := tok.(string)
+		fn(key, lineAt(l.bs, l.dec.InputOffset()))
+	}
+	_, _ = l.dec.Token() // "}"
+}
+
+// skip the value
+func (l *jsonLines) skip() {
+	var raw json.RawMessage
+	_ = l.dec.Decode(&raw)
+}
+
+// lineAt get the line number of the offset in bs.
+func lineAt(bs []byte, offset int64) int {
+	if offset > int64(len(bs)) {
+		offset = int64(len(bs))
+	}
+	return bytes.Count(bs[:offset], []byte{'\n'}) + 1
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+var testRuleFile = `{
+	"scenes": {"create": ["name", "email"]},
+	"messages": {"minLen": "{field} is too short"},
+	"fields": {
+		"name": {
+			"rules": "required|minLen:3",
+			"filters": "trim|lower",
+			"messages": "required:name is required",
+			"label": "User Name",
+			"scenes": ["update"]
+		},
+		"email": {"rules": "required|email"},
+		"age": {"rules": "int|between:1,120", "default": 18, "scenes": ["update"]}
+	}
+}`
+
+func TestLoadRuleFile(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := LoadRuleFile(strings.NewReader(testRuleFile))
+	is.NoErr(err)
+
+	r := rs.Validate(M{"name": " TOM ", "email": "tom@example.com"})
+	is.True(r.IsOK())
+	is.Eq("tom", r.SafeData()["name"])
+	is.Eq(18.0, r.SafeData()["age"])
+
+	is.ErrMsg(rs.ValidateErr(M{"email": "tom@example.com"}), "name is required")
+	is.ErrMsg(rs.ValidateErr(M{"name": "to"}), "User Name is too short")
+	is.ErrMsg(rs.ValidateErr(M{"name": "tom", "email": "tom"}), "email value is an invalid email address")
+
+	// scenes
+	is.NoErr(rs.ValidateErr(M{"name": "tom", "age": 20}, "update"))
+	is.ErrMsg(rs.ValidateErr(M{"name": "tom"}, "create"), "email is required to not be empty")
+
+	d := rs.Describe()
+	is.Eq([]string{"name", "email", "age"}, d.FieldNames())
+	is.Eq([]string{"name", "age"}, d.Scenes["update"])
+	is.Eq([]string{"trim", "lower"}, d.Field("name").Filters)
+}
+
+func TestLoadRuleFile_errors(t *testing.T) {
+	is := assert.New(t)
+
+	// the file name and line number
+	fpath := filepath.Join(t.TempDir(), "user.json")
+	is.NoErr(os.WriteFile(fpath, []byte(`{
+	"fields": {
+		"name": {
+			"rules": "required|minLn:3",
+			"filters": "trim|notExists"
+		},
+		"age": {"rules": "between:1", "lable": "Age"}
+	},
+	"field": {}
+}`), 0644))
+
+	file, err := os.Open(fpath)
+	is.NoErr(err)
+	defer file.Close()
+
+	_, err = LoadRuleFile(file)
+	is.ErrIs(err, ErrConfig)
+
+	errs := err.(ConfigErrors)
+	is.Len(errs, 5)
+	is.Eq(fpath+":9: unknown rule file key 'field'", errs[0].Error())
+	is.Eq(fpath+":4: field 'name': the validator 'minLn' does not exist", errs[1].Error())
+	is.Eq(5, errs[2].Line)
+	is.Eq("notExists", errs[2].Validator)
+	is.Eq(7, errs[3].Line)
+	is.Eq("lable", errs[3].Validator)
+	is.Eq(7, errs[4].Line)
+	is.Eq("between", errs[4].Validator)
+
+	// the bad args
+	_, err = LoadRuleFile(strings.NewReader("{\"fields\": {\n\"name\": {\"rules\": \"minLen:abc\"}}}"))
+	is.ErrIs(err, ErrConfig)
+	is.StrContains(err.Error(), "line 2: field 'name': ")
+	is.Eq(2, err.(ConfigErrors)[0].Line)
+
+	// bad JSON
+	_, err = LoadRuleFile(strings.NewReader("{\"fields\": {\n\"age\": {\"rules\": 1}}}"))
+	is.ErrIs(err, ErrInvalidData)
+	is.StrContains(err.Error(), "line 2: json: cannot unmarshal number")
+	_, err = LoadRuleFile(strings.NewReader("{\"fields\": \n{,}}"))
+	is.ErrIs(err, ErrInvalidData)
+	is.StrContains(err.Error(), "decode the rule file: line 2: invalid character")
+
+	// custom decoder, no line number
+	rs, err := LoadRuleFile(strings.NewReader(`{"fields": {"name": {"rules": "required"}}}`), func(bs []byte, rf *RuleFile) error {
+		return json.Unmarshal(bs, rf)
+	})
+	is.NoErr(err)
+	is.ErrMsg(rs.ValidateErr(M{}), "name is required to not be empty")
+
+	// custom decoder with the line numbers
+	_, err = LoadRuleFile(strings.NewReader(`{}`), func(bs []byte, rf *RuleFile) error {
+		rf.Name = "user.yaml"
+		rf.Unknown = map[string]int{"field": 1}
+		rf.Fields = map[string]*RuleFileField{
+			"name": {Rules: "minLn:3", Line: 2, Lines: map[string]int{"rules": 3}, Unknown: map[string]int{"rule": 4}},
+		}
+		return nil
+	})
+	errs = err.(ConfigErrors)
+	is.Len(errs, 3)
+	is.Eq("user.yaml:1: unknown rule file key 'field'", errs[0].Error())
+	is.Eq("user.yaml:4: field 'name': unknown rule file key 'rule'", errs[1].Error())
+	is.Eq("user.yaml:3: field 'name': the validator 'minLn' does not exist", errs[2].Error())
+
+	_, err = RuleSetFromFile(&RuleFile{Fields: map[string]*RuleFileField{"name": {Rules: "minLn:3"}}})
+	is.ErrIs(err, ErrConfig)
+	is.Eq("field 'name': the validator 'minLn' does not exist", err.Error())
+}
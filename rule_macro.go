@@ -0,0 +1,196 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RuleMacroPrefix the prefix of a rule macro reference in the rules. eg: "@username"
+const RuleMacroPrefix = "@"
+
+// RuleMacro is a named rule chain, can be referenced as "@name" in the rules
+// and the validate tags. see AddRuleMacro
+type RuleMacro struct {
+	// Name the macro name, without the prefix "@"
+	Name string
+	// Rule the validator rules, can reference other macros. eg: "required|@name"
+	Rule string
+	// Filter the filter rules applied before the field filters.
+	Filter string
+	// Messages the default messages of the validators. {validator: message}
+	Messages MS
+}
+
+// WithMessages set the default messages of the macro validators. The field
+// messages (eg: the message tag) will override them.
+//
+// Usage:
+//
+//	validate.AddRuleMacro("username", "required|minLen:3").WithMessages(validate.MS{
+//		"minLen": "{field} must be at least %d characters",
+//	})
+func (m *RuleMacro) WithMessages(msgs MS) *RuleMacro {
+	m.Messages = msgs
+	return m
+}
+
+// the global rule macros. {name: macro}
+//
+// NOTE: it is not locked, the macros must be added before the validation.
+// eg: in the init() func
+var ruleMacros = map[string]*RuleMacro{}
+
+// AddRuleMacro add a named rule chain to the global macros. The macro is
+// referenced as "@name" and can be combined with the other rules or macros.
+// The macros are expanded once when the rules are collected, the macros must
+// be added before the struct type is first validated.
+//
+// NOTE: it is not safe for concurrent use, add the macros on the app init,
+// before any validation.
+//
+// Usage:
+//
+//	validate.AddRuleMacro("username", "required|alphaDash|minLen:3|maxLen:32", "trim|lower")
+//
+//	type User struct {
+//		Name string `validate:"@username|notIn:admin,root"`
+//	}
+func AddRuleMacro(name, rule string, filterRule ...string) *RuleMacro {
+	if !goodName(name) {
+		panicf("rule macro name %s is not a valid identifier", name)
+	}
+
+	m := &RuleMacro{Name: name, Rule: rule}
+	if len(filterRule) > 0 {
+		m.Filter = filterRule[0]
+	}
+	ruleMacros[name] = m
+	return m
+}
+
+// GetRuleMacro get a rule macro by name, nil if not exists.
+func GetRuleMacro(name string) *RuleMacro {
+	return ruleMacros[strings.TrimPrefix(name, RuleMacroPrefix)]
+}
+
+// isRuleMacro check the rule segment is a macro reference. eg: "@username"
+func isRuleMacro(s string) bool {
+	return len(s) > 1 && strings.HasPrefix(s, RuleMacroPrefix)
+}
+
+// hasRuleMacro check the rule has a macro reference segment. The "@" in the
+// rule args is not a macro. eg: "contains:@", "regex:^.+@.+$"
+func hasRuleMacro(rule string) bool {
+	if !strings.Contains(rule, RuleMacroPrefix) {
+		return false
+	}
+
+	for _, seg := range splitRules(rule) {
+		if isRuleMacro(strings.TrimSpace(seg)) {
+			return true
+		}
+	}
+	return false
+}
+
+const errMacroNotExists = "does not exist"
+
+// ruleMacroError the error of a bad macro reference.
+type ruleMacroError struct {
+	macro string // eg: "@username"
+	msg   string
+}
+
+func (e *ruleMacroError) Error() string {
+	return fmt.Sprintf("the rule macro '%s' %s", e.macro, e.msg)
+}
+
+// expandRuleMacros replace the macro references in the rule by the macro rules,
+// nested macros are expanded recursively. fn is called for each used macro, the
+// nested ones first.
+//
+// The bad macro references are removed from the rule, and returned as errors.
+func expandRuleMacros(rule string, fn func(m *RuleMacro)) (string, []*ruleMacroError) {
+	return expandMacroRule(rule, nil, fn)
+}
+
+func expandMacroRule(rule string, stack []string, fn func(m *RuleMacro)) (string, []*ruleMacroError) {
+	if !hasRuleMacro(rule) {
+		return rule, nil
+	}
+
+	var errs []*ruleMacroError
+	rules := splitRules(strings.Trim(strings.TrimSpace(rule), "|:"))
+	segs := make([]string, 0, len(rules))
+	for _, seg := range rules {
+		seg = strings.TrimSpace(seg)
+		if !isRuleMacro(seg) {
+			// keep the escaped "|" in the args. eg: "regex:a\|b"
+			segs = append(segs, strings.ReplaceAll(seg, "|", `\|`))
+			continue
+		}
+
+		name := seg[len(RuleMacroPrefix):]
+		m := ruleMacros[name]
+		if m == nil {
+			errs = append(errs, &ruleMacroError{macro: seg, msg: errMacroNotExists})
+			continue
+		}
+		if slices.Contains(stack, name) {
+			errs = append(errs, &ruleMacroError{macro: seg, msg: "is recursive"})
+			continue
+		}
+
+		sub, subErrs := expandMacroRule(m.Rule, append(stack, name), fn)
+		if sub != "" {
+			segs = append(segs, sub)
+		}
+		errs = append(errs, subErrs...)
+		if fn != nil {
+			fn(m)
+		}
+	}
+	return strings.Join(segs, "|"), errs
+}
+
+// expandRuleMacros expand the macros in the rule of the field, returns the
+// expanded rule and the filters of the macros. The default messages of the
+// macros are added to the field if it has no the message.
+func (v *Validation) expandRuleMacros(field, rule string) (string, string) {
+	var filters []string
+	var msgs map[string]string
+	rule, errs := expandRuleMacros(rule, func(m *RuleMacro) {
+		if m.Filter != "" {
+			filters = append(filters, m.Filter)
+		}
+		for validator, msg := range m.Messages {
+			if msgs == nil {
+				msgs = make(map[string]string)
+			}
+			// the outer macro messages override the nested ones
+			msgs[field+"."+validator] = msg
+		}
+	})
+
+	for _, e := range errs {
+		v.configErrorf(field, e.macro, "%s", e.Error())
+	}
+	for key, msg := range msgs {
+		if !v.trans.HasMessage(key) {
+			v.trans.AddMessage(key, msg)
+		}
+	}
+	return rule, strings.Join(filters, "|")
+}
+
+// joinFilterRule join the filter rules, skip the empty one.
+func joinFilterRule(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "|" + b
+}
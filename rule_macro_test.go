@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type macroUser struct {
+	Name  string `validate:"@t_username|notIn:admin,root"`
+	Nick  string `validate:"@t_nick" message:"minLen:nick is too short"`
+	Email string `validate:"@t_email" filter:"upper"`
+}
+
+func addTestRuleMacros(t *testing.T) {
+	AddRuleMacro("t_username", "required|alphaDash|minLen:3|maxLen:32", "trim|lower").WithMessages(MS{
+		"minLen": "{field} must be at least %d characters",
+	})
+	AddRuleMacro("t_nick", "@t_username|maxLen:8").WithMessages(MS{
+		"maxLen": "{field} is too long",
+	})
+	AddRuleMacro("t_email", "required|email", "trim")
+	AddRuleMacro("t_loop1", "required|@t_loop2")
+	AddRuleMacro("t_loop2", "minLen:2|@t_loop1")
+
+	t.Cleanup(func() {
+		for _, name := range []string{"t_username", "t_nick", "t_email", "t_loop1", "t_loop2"} {
+			delete(ruleMacros, name)
+		}
+		ResetTypeCache()
+	})
+}
+
+func TestAddRuleMacro(t *testing.T) {
+	is := assert.New(t)
+	addTestRuleMacros(t)
+
+	is.Eq("trim|lower", GetRuleMacro("@t_username").Filter)
+	is.Nil(GetRuleMacro("not_exists"))
+	is.Panics(func() {
+		AddRuleMacro("bad name", "required")
+	})
+
+	r := Check(&macroUser{Name: " Tom ", Nick: "tommy", Email: " tom@example.com"})
+	is.True(r.IsOK())
+	is.Eq("tom", r.SafeVal("Name"))
+	is.Eq("tommy", r.SafeVal("Nick"))
+	is.Eq("TOM@EXAMPLE.COM", r.SafeVal("Email"))
+
+	// combined with the extra rules
+	r = Check(&macroUser{Name: "admin", Nick: "tommy", Email: "tom@example.com"})
+	is.True(r.Fail())
+	is.Contains(r.Errors.Field("Name"), "notIn")
+
+	// the default messages
+	r = Check(&macroUser{Name: "to", Nick: "tommy", Email: "tom@example.com"})
+	is.Eq("Name must be at least 3 characters", r.Errors.One())
+
+	// nested macro, the message tag overrides the macro messages
+	r = Check(&macroUser{Name: "tom", Nick: "to", Email: "tom@example.com"})
+	is.Eq("nick is too short", r.Errors.One())
+	r = Check(&macroUser{Name: "tom", Nick: "tommy_cat", Email: "tom@example.com"})
+	is.Eq("Nick is too long", r.Errors.One())
+
+	// expanded once on the rule template build
+	d := Struct(&macroUser{}).Describe().Field("Nick")
+	is.Eq([]string{"required", "isAlphaDash", "minLength", "maxLength", "maxLength"}, d.Validators())
+
+	// on the StringRule
+	v := Map(M{"name": " TO "})
+	v.StringRule("name", "@t_nick", "upper")
+	is.False(v.Validate())
+	is.Eq("name must be at least 3 characters", v.Errors.One())
+	is.Eq([]string{"trim", "lower", "upper"}, v.Describe().Field("name").Filters)
+
+	// the field messages set before are kept
+	v = Map(M{"name": "to"})
+	v.AddMessages(map[string]string{"name.minLen": "name is too short"})
+	v.StringRule("name", "@t_username")
+	is.False(v.Validate())
+	is.Eq("name is too short", v.Errors.One())
+}
+
+func TestAddRuleMacro_errors(t *testing.T) {
+	is := assert.New(t)
+	addTestRuleMacros(t)
+
+	_, err := CompileRuleSet(func(v *Validation) {
+		v.StringRule("name", "@t_username|@t_notExists")
+		v.StringRule("code", "@t_loop1")
+	})
+	is.ErrIs(err, ErrConfig)
+
+	errs := err.(ConfigErrors)
+	is.Len(errs, 2)
+	is.Eq("field 'name': the rule macro '@t_notExists' does not exist", errs[0].Error())
+	is.Eq("@t_loop1", errs[1].Validator)
+	is.Eq("field 'code': the rule macro '@t_loop1' is recursive", errs[1].Error())
+
+	// the escaped "|" in the args is kept
+	v := Map(M{"code": "b"})
+	v.StringRule("code", `@t_email|regex:^(a\|b)$`)
+	is.Eq("^(a|b)$", v.Describe().Field("code").Rules[2].Args[0])
+
+	// the tag linter
+	items, err := ParseTagRule("@t_nick|@t_other|email")
+	is.NoErr(err)
+	is.Len(items, 6)
+	is.Eq("email", items[5].Validator)
+	_, err = ParseTagRule("@t_loop2")
+	is.ErrMsg(err, "the rule macro '@t_loop2' is recursive")
+}
+
+type macroAtUser struct {
+	Email string `validate:"contains:@|regex:^.+@.+$"`
+}
+
+func TestAddRuleMacro_atInArgs(t *testing.T) {
+	is := assert.New(t)
+
+	is.False(hasRuleMacro("contains:@"))
+	is.False(hasRuleMacro(`regex:^.+@.+$|required`))
+	is.True(hasRuleMacro("required| @t_email"))
+
+	// the "@" in the args is not a macro, the rule is kept as is
+	rule, errs := expandRuleMacros(`required|regex:^(a\|@)$`, nil)
+	is.Empty(errs)
+	is.Eq(`required|regex:^(a\|@)$`, rule)
+
+	v := Map(M{"email": "tom@example.com"})
+	v.CollectConfigErr = true
+	v.StringRule("email", "required|contains:@")
+	is.True(v.Validate())
+	is.Eq("@", v.Describe().Field("email").Rules[1].Args[0])
+
+	r := Check(&macroAtUser{Email: "tom.example.com"})
+	is.True(r.Fail())
+	is.Contains(r.Errors.Field("Email"), "contains")
+	is.True(Check(&macroAtUser{Email: "tom@example.com"}).IsOK())
+}
@@ -0,0 +1,318 @@
+package validate
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// RuleSet is the compiled rules, can be applied to any DataFace many times.
+// eg: map, form, JSON data.
+//
+// The rules are parsed, the validators are resolved and the args are
+// pre-converted once on compile, same as the struct rule template. It is
+// read-only after compiled and safe for concurrent use.
+//
+// Usage:
+//
+//	var userRules = validate.MustRuleSet(validate.NewRuleSet(validate.MS{
+//		"name":  "required|minLen:2",
+//		"email": "required|email",
+//	}))
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		res := userRules.Validate(r)
+//		if res.Fail() {
+//			validate.WriteProblem(w, res)
+//			return
+//		}
+//	}
+type RuleSet struct {
+	tpl    *ruleTemplate
+	scenes SValues
+	// the custom validators and filters added on compile
+	validators map[string]*funcMeta
+	filters    map[string]reflect.Value
+}
+
+// CompileRuleSet compile the rules configured by fn on an empty Validation.
+// Can use any rule methods in fn. eg: StringRules, FilterRules, AddFields,
+// WithScenes, WithMessages, AddValidator.
+//
+// The bad rules are returned as the ConfigErrors, see Validation.Precompile.
+//
+// Usage:
+//
+//	rs, err := validate.CompileRuleSet(func(v *validate.Validation) {
+//		v.StringRules(validate.MS{"name": "required|minLen:2"})
+//		v.FilterRule("name", "trim")
+//		v.WithMessages(validate.MS{"name.required": "name is required"})
+//	})
+func CompileRuleSet(fn func(v *Validation)) (*RuleSet, error) {
+	tv := newValidation(FromMap(M{}))
+	tv.CollectConfigErr = true
+
+	err := catchConfigPanic(tv, func() { fn(tv) })
+	if err == nil {
+		err = tv.Precompile()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newRuleSet(tv), nil
+}
+
+// NewRuleSet compile the rules string map to a RuleSet.
+//
+// Usage:
+//
+//	rs, err := validate.NewRuleSet(validate.MS{
+//		"name": "required|minLen:2",
+//		"tags": "dive|in:go,php",
+//	})
+func NewRuleSet(rules MS) (*RuleSet, error) {
+	return CompileRuleSet(func(v *Validation) {
+		v.StringRules(rules)
+	})
+}
+
+// RuleSetFromFields compile the rules built by the FieldBuilder to a RuleSet.
+func RuleSetFromFields(fbs ...*FieldBuilder) (*RuleSet, error) {
+	return CompileRuleSet(func(v *Validation) {
+		v.AddFields(fbs...)
+	})
+}
+
+// RuleSetFromStruct compile the rules from the struct tags to a RuleSet, use
+// for validate the map data of the struct. eg: the JSON request body.
+//
+// The rule fields are named by the FieldTag(default: json) same as the data
+// keys, the slice or map of struct fields use the wildcard. eg: "items.*.sku",
+// the element rules are skipped on the slice or map is empty.
+//
+// Only the tags are used, the struct methods are not called. eg: ConfigValidation
+func RuleSetFromStruct(s any) (*RuleSet, error) {
+	rt, ok := structTypeOf(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: RuleSetFromStruct the type %v is not a struct", ErrInvalidData, rt)
+	}
+
+	return CompileRuleSet(func(v *Validation) {
+		d := &StructData{
+			valueTyp:    rt,
+			ValidateTag: gOpt.ValidateTag,
+			FilterTag:   gOpt.FilterTag,
+			fieldNames:  make(map[string]int8),
+		}
+		d.value = filledValue(rt, map[reflect.Type]bool{rt: true})
+		d.src = d.value.Addr().Interface()
+		d.parseRulesFromTag(v)
+
+		// rename to the data keys, the error keys are same as the rule fields.
+		v.renameFields(outPathRenamer(v.trans.fieldMap), d.fieldNames)
+		v.trans.fieldMap = nil
+		v.errKeys = nil
+
+		// skip the element rules on the slice or map is empty, same as the struct.
+		for _, r := range v.rules {
+			for _, field := range r.fields {
+				if pos := strings.Index(field, ".*"); pos > 0 {
+					v.ensureOptionals() // lazy
+					v.optionals[field[:pos]] = 0
+				}
+			}
+		}
+	})
+}
+
+// MustRuleSet returns the RuleSet, panic on error.
+func MustRuleSet(rs *RuleSet, err error) *RuleSet {
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func newRuleSet(tv *Validation) *RuleSet {
+	rs := &RuleSet{tpl: newRuleTemplate(tv), scenes: tv.scenes}
+	for name, typ := range tv.validators {
+		// the builtin ctx validators are bound to tv, skip them.
+		if typ == validatorTypeCustom {
+			if rs.validators == nil {
+				rs.validators = make(map[string]*funcMeta)
+			}
+			rs.validators[name] = tv.validatorMetas[name]
+		}
+	}
+	if len(tv.filterValues) > 0 {
+		rs.filters = tv.filterValues
+	}
+	return rs
+}
+
+// Create a Validation with the rules for the data. The instance is from the
+// package pool, call ValidateR() or Release() when done.
+func (rs *RuleSet) Create(data DataFace, scene ...string) *Validation {
+	return rs.createIn(defaultFactory, data).SetScene(scene...)
+}
+
+func (rs *RuleSet) createIn(f *Factory, data DataFace) *Validation {
+	v := f.get()
+	v.data = data
+	rs.tpl.applyTo(v)
+
+	if rs.scenes != nil {
+		v.scenes = rs.scenes
+	}
+	if len(rs.validators) > 0 {
+		v.ensureValidatorMaps() // lazy
+		for name, fm := range rs.validators {
+			v.validators[name] = validatorTypeCustom
+			v.validatorMetas[name] = fm
+		}
+	}
+	for name, fv := range rs.filters {
+		if v.filterValues == nil {
+			v.filterValues = make(map[string]reflect.Value, len(rs.filters))
+		}
+		v.filterValues[name] = fv
+	}
+	return v
+}
+
+// Validate the data with the rules, returns the result. like Check()
+//
+// data type support:
+//   - DataFace
+//   - M/map[string]any
+//   - SValues/url.Values/map[string][]string
+//   - []byte, string: JSON data
+//   - *http.Request
+func (rs *RuleSet) Validate(data any, scene ...string) *ValidResult {
+	d, err := ruleSetData(data)
+	if err != nil {
+		return NewValidation(nil).WithError(err).ValidateR()
+	}
+	return rs.Create(d, scene...).ValidateR()
+}
+
+// ValidateErr validate the data with the rules, returns the first error.
+// like CheckErr(), see RuleSet.Validate for the data type.
+func (rs *RuleSet) ValidateErr(data any, scene ...string) error {
+	d, err := ruleSetData(data)
+	if err != nil {
+		return err
+	}
+
+	v := rs.Create(d, scene...)
+	v.Validate()
+	err = v.firstError()
+	v.Release()
+	return err
+}
+
+// ruleSetData convert the data to DataFace
+func ruleSetData(data any) (DataFace, error) {
+	switch td := data.(type) {
+	case DataFace:
+		return td, nil
+	case M:
+		return FromMap(td), nil
+	case map[string]any:
+		return FromMap(td), nil
+	case SValues:
+		return FromURLValues(url.Values(td)), nil
+	case url.Values:
+		return FromURLValues(td), nil
+	case map[string][]string:
+		return FromURLValues(td), nil
+	case []byte:
+		return FromJSONBytes(td)
+	case string:
+		return FromJSON(td)
+	case *http.Request:
+		return FromRequest(td)
+	}
+	return nil, fmt.Errorf("%w: RuleSet unsupported data type %T", ErrInvalidData, data)
+}
+
+// outPathRenamer returns the func to rename the struct field path to the output
+// path by fieldMap. The index of the filled slice element is replaced with "*".
+// eg: "Items.0.Sku" -> "items.*.sku"
+func outPathRenamer(fieldMap map[string]string) func(string) string {
+	return func(name string) string {
+		nodes := strings.Split(name, ".")
+		// find the longest prefix has output name
+		for i := len(nodes); i > 0; i-- {
+			if out, ok := fieldMap[strings.Join(nodes[:i], ".")]; ok {
+				nodes = append(strings.Split(out, "."), nodes[i:]...)
+				break
+			}
+		}
+
+		for i, node := range nodes {
+			if node == "0" {
+				nodes[i] = "*"
+			}
+		}
+		return strings.Join(nodes, ".")
+	}
+}
+
+// renameFields rename the field names in the rules, filter rules, optionals,
+// default values, labels and messages.
+func (v *Validation) renameFields(rename func(string) string, fieldNames map[string]int8) {
+	renameAll := func(fields []string) {
+		for i, field := range fields {
+			fields[i] = rename(field)
+		}
+	}
+
+	for _, r := range v.rules {
+		renameAll(r.fields)
+	}
+	for _, r := range v.filterRules {
+		renameAll(r.fields)
+	}
+
+	if len(v.optionals) > 0 {
+		optionals := make(map[string]int8, len(v.optionals))
+		for field, val := range v.optionals {
+			optionals[rename(field)] = val
+		}
+		v.optionals = optionals
+	}
+	if len(v.defValues) > 0 {
+		defValues := make(map[string]any, len(v.defValues))
+		for field, val := range v.defValues {
+			defValues[rename(field)] = val
+		}
+		v.defValues = defValues
+	}
+
+	if len(v.trans.labelMap) > 0 {
+		labels := make(map[string]string, len(v.trans.labelMap))
+		for field, label := range v.trans.labelMap {
+			labels[rename(field)] = label
+		}
+		v.trans.labelMap = labels
+	}
+
+	// the message key of field: "field" or "field.validator"
+	if len(v.trans.messages) > 0 {
+		msgs := make(map[string]string, len(v.trans.messages))
+		for key, msg := range v.trans.messages {
+			if _, ok := fieldNames[key]; ok {
+				key = rename(key)
+			} else if pos := strings.LastIndexByte(key, '.'); pos > 0 {
+				if _, ok := fieldNames[key[:pos]]; ok {
+					key = rename(key[:pos]) + key[pos:]
+				}
+			}
+			msgs[key] = msg
+		}
+		v.trans.messages = msgs
+	}
+}
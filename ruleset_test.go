@@ -0,0 +1,168 @@
+package validate
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+func TestNewRuleSet(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := NewRuleSet(MS{
+		"name": "required|minLen:3",
+		"age":  "required|between:1,99",
+		"tags": "dive|in:go,php",
+	})
+	is.NoErr(err)
+
+	r := rs.Validate(M{"name": "tom", "age": 20, "tags": []string{"go"}})
+	is.True(r.IsOK())
+	is.Eq("tom", r.SafeData()["name"])
+
+	r = rs.Validate(M{"name": "to", "age": 20, "tags": []string{"java"}})
+	is.True(r.Fail())
+	is.True(r.Errors.HasField("name"))
+
+	// form data
+	is.NoErr(rs.ValidateErr(url.Values{"name": {"tom"}, "age": {"20"}}))
+	is.ErrMsg(rs.ValidateErr(url.Values{"name": {"to"}, "age": {"20"}}), "name min length is 3")
+
+	// JSON data
+	is.NoErr(rs.ValidateErr(`{"name": "tom", "age": 20}`))
+	is.ErrMsg(rs.ValidateErr([]byte(`{"name": "to", "age": 20}`)), "name min length is 3")
+	is.Err(rs.ValidateErr(`{invalid`))
+
+	// request
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "tom", "age": 20}`))
+	req.Header.Set("Content-Type", "application/json")
+	is.True(rs.Validate(req).IsOK())
+
+	// unsupported data
+	err = rs.ValidateErr(123)
+	is.ErrIs(err, ErrInvalidData)
+	r = rs.Validate(123)
+	is.True(r.Fail())
+
+	// bad rules
+	_, err = NewRuleSet(MS{"name": "required|notExists", "age": "min:1,2"})
+	is.ErrIs(err, ErrConfig)
+	is.Len(err.(ConfigErrors), 2)
+	is.Panics(func() {
+		MustRuleSet(NewRuleSet(MS{"tags": "dive|keys|alpha"}))
+	})
+}
+
+func TestCompileRuleSet(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := CompileRuleSet(func(v *Validation) {
+		v.StringRules(MS{"name": "required|isTom", "code": "required|isCode"})
+		v.FilterRule("name", "trim|myLower")
+		v.AddValidator("isTom", func(val any) bool { return val == "tom" })
+		v.AddValidator("isCode", func(fc FieldCtx) bool { return fc.Value().String() == "a1" })
+		v.AddFilter("myLower", strings.ToLower)
+		v.WithScenes(SValues{"login": {"name"}})
+		v.WithMessages(MS{"name.isTom": "{field} must be tom"})
+		v.WithTranslates(MS{"name": "Name"})
+	})
+	is.NoErr(err)
+
+	r := rs.Validate(M{"name": " TOM ", "code": "a1"})
+	is.True(r.IsOK())
+	is.Eq("tom", r.SafeData()["name"])
+
+	r = rs.Validate(M{"name": "jerry", "code": "a1"})
+	is.Eq("Name must be tom", r.Errors.FieldOne("name"))
+
+	// scene
+	is.NoErr(rs.ValidateErr(M{"name": "tom"}, "login"))
+	is.Err(rs.ValidateErr(M{"name": "tom"}))
+
+	// the pooled instance
+	v := rs.Create(FromMap(M{"name": "tom"}), "login")
+	is.True(v.Validate())
+	v.Release()
+
+	// panic on compile
+	_, err = CompileRuleSet(func(v *Validation) {
+		v.AddFields(Field("name").Message("msg"))
+	})
+	is.ErrIs(err, ErrConfig)
+	is.ErrSubMsg(err, "Message() must be called after a rule")
+
+	// concurrent use
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.NoErr(t, rs.ValidateErr(M{"name": "tom", "code": "a1"}))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRuleSetFromFields(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := RuleSetFromFields(
+		Field("name").Required().MinLen(3).Filter("trim"),
+		Field("age").Int().Between(1, 99).Default(18),
+	)
+	is.NoErr(err)
+
+	r := rs.Validate(M{"name": " tom "})
+	is.True(r.IsOK())
+	is.Eq("tom", r.SafeData()["name"])
+	is.Eq(18, r.SafeData()["age"])
+	is.Err(rs.ValidateErr(M{"name": "to"}))
+}
+
+type rsItem struct {
+	Sku string `json:"sku" validate:"required|minLen:3" label:"SKU"`
+}
+
+type rsAddress struct {
+	City string `validate:"required"`
+}
+
+type rsOrder struct {
+	ID      string            `json:"id" validate:"required" filter:"trim" message:"required:id is required"`
+	Items   []rsItem          `json:"items" validate:"required"`
+	Extra   map[string]rsItem `json:"extra" validate:"-"`
+	Address rsAddress         `json:"address" validate:""`
+}
+
+func TestRuleSetFromStruct(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := RuleSetFromStruct(&rsOrder{})
+	is.NoErr(err)
+
+	var fields []string
+	for _, r := range rs.tpl.rules {
+		fields = append(fields, r.fields...)
+	}
+	is.Eq([]string{"id", "items", "items.*.sku", "items.*.sku", "extra", "extra.*.sku", "extra.*.sku", "address.City"}, fields)
+
+	r := rs.Validate(`{"id": " a1 ", "items": [{"sku": "abc"}], "address": {"City": "x"}}`)
+	is.Nil(r.Err())
+	is.Eq("a1", r.SafeData()["id"])
+
+	r = rs.Validate(`{"items": [{"sku": "abc"}, {"sku": "ab"}], "address": {"City": "x"}}`)
+	is.Eq("id is required", r.Errors.FieldOne("id"))
+
+	r = rs.Validate(M{"id": "a1", "items": []any{M{"sku": "ab"}}, "address": M{"City": "x"}})
+	is.True(r.Fail())
+	is.StrContains(r.Errors.One(), "SKU")
+
+	_, err = RuleSetFromStruct("abc")
+	is.ErrIs(err, ErrInvalidData)
+}
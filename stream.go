@@ -0,0 +1,205 @@
+package validate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// StreamOption settings for the ValidateStream
+type StreamOption struct {
+	// Rules the rules for validate the map records. see Validation.StringRules
+	//
+	// eg: {"name": "required|minLen:3", "age": "int|min:1"}
+	Rules MS
+	// RuleSet the compiled rules for validate the map records, the Rules is
+	// ignored if it is set. see NewRuleSet
+	RuleSet *RuleSet
+	// Struct decode the records into the struct type, validate by the struct tags.
+	// the Rules is ignored if it is set. eg: User{}, (*User)(nil)
+	Struct any
+	// Scene the validate scene name
+	Scene string
+}
+
+// StreamRecord the validate result of one record in the stream.
+type StreamRecord struct {
+	// No the record number, start from 1
+	No int
+	// Offset the byte offset of the record in the stream
+	Offset int64
+	// Raw the raw JSON bytes of the record
+	Raw []byte
+	// Value the decoded record. *MapData for the map records, pointer of
+	// the StreamOption.Struct type for the struct records.
+	Value any
+	// Result the validate result, nil on the Err is not nil
+	Result *ValidResult
+	// Err the error on decode the record. eg: invalid JSON line in the NDJSON
+	Err error
+}
+
+// Fail reports whether the record decode failed or validate failed.
+func (rec *StreamRecord) Fail() bool { return rec.Err != nil || rec.Result.Fail() }
+
+// ValidateStream validate each JSON record in the NDJSON or top-level JSON array
+// stream r by the package pool. see Factory.ValidateStream
+//
+// Usage:
+//
+//	err := validate.ValidateStream(file, func(rec *validate.StreamRecord) bool {
+//		if rec.Fail() {
+//			fmt.Println(rec.No, rec.Offset, rec.Err, rec.Result.Errors)
+//		}
+//		return true // continue
+//	}, func(opt *validate.StreamOption) {
+//		opt.Rules = validate.MS{"name": "required|minLen:3"}
+//	})
+func ValidateStream(r io.Reader, each func(rec *StreamRecord) bool, fns ...func(opt *StreamOption)) error {
+	return defaultFactory.ValidateStream(r, each, fns...)
+}
+
+// ValidateStream validate each JSON record in the NDJSON or top-level JSON array
+// stream r, without loading the whole document. The records are decoded by the
+// Unmarshal hook, then validated on the pooled instances with the compiled rules,
+// the result of each record is passed to the each func in order. return false
+// from the each func to stop.
+//
+// The invalid record(eg: invalid JSON line in NDJSON) is reported by the
+// StreamRecord.Err and continue. returns error on read failed, the JSON array
+// is malformed, the Rules is invalid or the Struct is not a struct.
+func (f *Factory) ValidateStream(r io.Reader, each func(rec *StreamRecord) bool, fns ...func(opt *StreamOption)) error {
+	opt := &StreamOption{}
+	for _, fn := range fns {
+		fn(opt)
+	}
+
+	check, err := f.streamChecker(opt)
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+
+	// skip the leading white spaces, detect the JSON array.
+	var skipped int64
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if !isJSONSpace(c) {
+			_ = br.UnreadByte()
+			if c == '[' {
+				return streamJSONArray(br, skipped, check, each)
+			}
+			break
+		}
+		skipped++
+	}
+
+	return streamNDJSON(br, skipped, check, each)
+}
+
+// streamChecker build the validate func for the records
+func (f *Factory) streamChecker(opt *StreamOption) (func(rec *StreamRecord), error) {
+	if opt.Struct != nil {
+		rt := removeTypePtr(reflect.TypeOf(opt.Struct))
+		if rt.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: ValidateStream the Struct must be a struct, but got %T", ErrInvalidData, opt.Struct)
+		}
+
+		return func(rec *StreamRecord) {
+			ptr := reflect.New(rt).Interface()
+			if rec.Err = Unmarshal(rec.Raw, ptr); rec.Err == nil {
+				rec.Value = ptr
+				rec.Result = f.Struct(ptr, opt.Scene).ValidateR()
+			}
+		}, nil
+	}
+
+	rs := opt.RuleSet
+	if rs == nil {
+		var err error
+		if rs, err = NewRuleSet(opt.Rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(rec *StreamRecord) {
+		var md *MapData
+		if md, rec.Err = FromJSONBytes(rec.Raw); rec.Err == nil {
+			rec.Value = md
+			rec.Result = rs.createIn(f, md).SetScene(opt.Scene).ValidateR()
+		}
+	}, nil
+}
+
+func streamNDJSON(br *bufio.Reader, offset int64, check func(rec *StreamRecord), each func(rec *StreamRecord) bool) error {
+	var no int
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			start := offset
+			offset += int64(len(line))
+
+			// trim the spaces, keep the offset of the record start
+			trimmed := bytes.TrimLeft(line, " \t\r\n")
+			start += int64(len(line) - len(trimmed))
+			trimmed = bytes.TrimRight(trimmed, " \t\r\n")
+
+			if len(trimmed) > 0 {
+				no++
+				rec := &StreamRecord{No: no, Offset: start, Raw: trimmed}
+				check(rec)
+				if !each(rec) {
+					return nil
+				}
+			}
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func streamJSONArray(br *bufio.Reader, base int64, check func(rec *StreamRecord), each func(rec *StreamRecord) bool) error {
+	dec := json.NewDecoder(br)
+	// the '['
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	var no int
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+
+		no++
+		rec := &StreamRecord{No: no, Offset: base + dec.InputOffset() - int64(len(raw)), Raw: raw}
+		check(rec)
+		if !each(rec) {
+			return nil
+		}
+	}
+
+	// the ']'
+	_, err := dec.Token()
+	return err
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
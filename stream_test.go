@@ -0,0 +1,162 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type streamUser struct {
+	Name string `json:"name" validate:"required|minLen:3"`
+	Age  int    `json:"age" validate:"int|min:1"`
+}
+
+func collectStream(t *testing.T, input string, fns ...func(opt *StreamOption)) []*StreamRecord {
+	var recs []*StreamRecord
+	err := ValidateStream(strings.NewReader(input), func(rec *StreamRecord) bool {
+		recs = append(recs, rec)
+		return true
+	}, fns...)
+	assert.NoErr(t, err)
+	return recs
+}
+
+func TestValidateStream_ndjson(t *testing.T) {
+	is := assert.New(t)
+
+	input := "{\"name\":\"tom\",\"age\":20}\n" +
+		"\n" +
+		"{\"name\":\"ab\",\"age\":0}\r\n" +
+		"  not json\n" +
+		"{\"name\":\"jerry\",\"age\":3}"
+
+	recs := collectStream(t, input, func(opt *StreamOption) {
+		opt.Rules = MS{"name": "required|minLen:3", "age": "required|min:1"}
+	})
+	is.Len(recs, 4)
+
+	is.Eq(1, recs[0].No)
+	is.Eq(int64(0), recs[0].Offset)
+	is.False(recs[0].Fail())
+	is.Eq("tom", recs[0].Value.(*MapData).Map["name"])
+
+	is.Eq(2, recs[1].No)
+	is.Eq(int64(25), recs[1].Offset)
+	is.True(recs[1].Fail())
+	is.True(recs[1].Result.Errors.HasField("name"))
+
+	is.Eq(3, recs[2].No)
+	is.Eq(int64(50), recs[2].Offset)
+	is.Err(recs[2].Err)
+	is.Nil(recs[2].Result)
+	is.True(recs[2].Fail())
+
+	is.Eq(4, recs[3].No)
+	is.False(recs[3].Fail())
+	is.Eq(`{"name":"jerry","age":3}`, string(recs[3].Raw))
+}
+
+func TestValidateStream_array(t *testing.T) {
+	is := assert.New(t)
+
+	input := ` [
+  {"name": "tom", "age": 20},
+  {"name": "ab", "age": 2},
+  {"name": "jerry", "age": "x"}
+]`
+	recs := collectStream(t, input, func(opt *StreamOption) {
+		opt.Struct = (*streamUser)(nil)
+	})
+	is.Len(recs, 3)
+	is.False(recs[0].Fail())
+	is.Eq("tom", recs[0].Value.(*streamUser).Name)
+	is.Eq(int64(strings.Index(input, `{"name": "tom"`)), recs[0].Offset)
+	is.Eq(int64(strings.Index(input, `{"name": "ab"`)), recs[1].Offset)
+	is.True(recs[1].Result.Errors.HasField("name"))
+	// decode failed
+	is.Err(recs[2].Err)
+
+	// stop by the each func
+	var n int
+	err := ValidateStream(strings.NewReader(input), func(rec *StreamRecord) bool {
+		n++
+		return false
+	}, func(opt *StreamOption) { opt.Struct = streamUser{} })
+	is.NoErr(err)
+	is.Eq(1, n)
+
+	// malformed array
+	err = ValidateStream(strings.NewReader(`[{"name": "tom"}, {`), func(rec *StreamRecord) bool {
+		return true
+	}, func(opt *StreamOption) { opt.Struct = streamUser{} })
+	is.Err(err)
+
+	// not a struct
+	err = ValidateStream(strings.NewReader(input), func(rec *StreamRecord) bool {
+		return true
+	}, func(opt *StreamOption) { opt.Struct = "user" })
+	is.ErrIs(err, ErrInvalidData)
+	is.ErrMsg(err, "invalid input data: ValidateStream the Struct must be a struct, but got string")
+
+	// empty input
+	is.Len(collectStream(t, "  \n "), 0)
+	is.Len(collectStream(t, "[]"), 0)
+
+	// custom Unmarshal hook
+	old := Unmarshal
+	defer func() { Unmarshal = old }()
+	Unmarshal = func(bs []byte, ptr any) error { return errors.New("unmarshal error") }
+	recs = collectStream(t, `{"name": "tom"}`)
+	is.Eq("unmarshal error", recs[0].Err.Error())
+}
+
+func TestValidateStream_ruleSet(t *testing.T) {
+	is := assert.New(t)
+
+	err := ValidateStream(strings.NewReader(`{"name": "tom"}`), func(rec *StreamRecord) bool {
+		return true
+	}, func(opt *StreamOption) {
+		opt.Rules = MS{"name": "required|notExists"}
+	})
+	is.ErrIs(err, ErrConfig)
+
+	rs := MustRuleSet(NewRuleSet(MS{"name": "required|minLen:3"}))
+	var fails []int
+	err = ValidateStream(strings.NewReader("{\"name\": \"tom\"}\n{\"name\": \"to\"}"), func(rec *StreamRecord) bool {
+		if rec.Fail() {
+			fails = append(fails, rec.No)
+		}
+		return true
+	}, func(opt *StreamOption) {
+		opt.RuleSet = rs
+	})
+	is.NoErr(err)
+	is.Eq([]int{2}, fails)
+}
+
+func TestRuleSet_template(t *testing.T) {
+	is := assert.New(t)
+
+	rs, err := NewRuleSet(MS{"age": "int|min:1|default:5", "name": "optional|minLen:3"})
+	is.NoErr(err)
+	tpl := rs.tpl
+	is.Len(tpl.rules, 4)
+	is.True(tpl.rules[0].argsReady || tpl.rules[1].argsReady)
+
+	for _, age := range []int{-1, 3} {
+		v := Map(M{"age": age})
+		tpl.applyTo(v)
+		if age < 0 {
+			is.False(v.Validate())
+		} else {
+			is.True(v.Validate())
+		}
+	}
+
+	v := Map(M{"name": "tom"})
+	tpl.applyTo(v)
+	is.True(v.Validate())
+	is.Eq("5", v.safeData["age"])
+}
@@ -0,0 +1,233 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/validate/v2/internal/reflectx"
+)
+
+// StructLevelFace definition. you can validate the whole struct after the
+// field rules passed. eg: cross-field invariants.
+//
+// It also runs for the nested structs and the struct elements of a slice or
+// map, which are validated by the field rules (see CheckSubOnParentMarked).
+// The nested structs run before the parent struct.
+//
+// Usage:
+//
+//	func (o *Order) ValidateStruct(sl validate.StructLevel) error {
+//		if o.Total != o.sumItems() {
+//			sl.AddError("Total", "sum", "total must be equal to the sum of items")
+//		}
+//		return nil
+//	}
+type StructLevelFace interface {
+	ValidateStruct(sl StructLevel) error
+}
+
+// StructLevel is the context for StructLevelFace.ValidateStruct
+type StructLevel interface {
+	// Validation returns the current Validation
+	Validation() *Validation
+	// Top returns the top-level struct value
+	Top() reflect.Value
+	// Current returns the current struct value
+	Current() reflect.Value
+	// Path returns the field path of the current struct, "" for the top-level
+	// struct. eg: "Address", "Items.0"
+	Path() string
+	// AddError add an error for the field of the current struct.
+	// field is relative to the current struct, "" for the struct itself.
+	// eg: "Total", "Items.0.Qty"
+	AddError(field, validator, msg string)
+}
+
+type structLevel struct {
+	v    *Validation
+	top  reflect.Value
+	cur  reflect.Value
+	path string
+	// the validate tag name of the source StructData
+	tag string
+}
+
+var _ StructLevel = (*structLevel)(nil)
+
+func (sl *structLevel) Validation() *Validation { return sl.v }
+func (sl *structLevel) Top() reflect.Value      { return sl.top }
+func (sl *structLevel) Current() reflect.Value  { return sl.cur }
+func (sl *structLevel) Path() string            { return sl.path }
+
+func (sl *structLevel) AddError(field, validator, msg string) {
+	if sl.path != "" {
+		field = joinOutPath(sl.path, field)
+	}
+	if field == "" {
+		field = validateError
+	}
+	sl.v.AddError(field, validator, msg)
+}
+
+// implStructLevel check the type or its pointer implements StructLevelFace
+func implStructLevel(rt reflect.Type) bool {
+	return rt.Implements(slFaceType) || reflect.PtrTo(rt).Implements(slFaceType)
+}
+
+// hasStructLevel check the type or any nested struct type implements
+// StructLevelFace. slice/array/map/pointer types check the element type.
+func hasStructLevel(rt reflect.Type) bool {
+	for {
+		switch rt.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			rt = rt.Elem()
+			continue
+		case reflect.Struct:
+			if rt == timeType {
+				return false
+			}
+			return getTypeMeta(rt).hasStructLevel
+		}
+		return false
+	}
+}
+
+// computeHasStructLevel reports whether rt or any struct type in its field
+// graph implements StructLevelFace.
+func computeHasStructLevel(rt reflect.Type) bool {
+	var scan func(t reflect.Type, seen map[reflect.Type]bool) bool
+	scan = func(t reflect.Type, seen map[reflect.Type]bool) bool {
+		if implStructLevel(t) {
+			return true
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i).Type
+			for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array || ft.Kind() == reflect.Map {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() != reflect.Struct || ft == timeType || seen[ft] {
+				continue
+			}
+			seen[ft] = true
+			if scan(ft, seen) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return scan(rt, map[reflect.Type]bool{rt: true})
+}
+
+// validateStructLevel run the struct-level validation after the field rules.
+func (d *StructData) validateStructLevel(v *Validation) {
+	if !d.value.IsValid() {
+		return
+	}
+	if d.meta != nil && !d.meta.hasStructLevel {
+		return
+	}
+
+	tag := d.ValidateTag
+	if tag == "" {
+		tag = gOpt.ValidateTag
+	}
+
+	sl := &structLevel{v: v, top: d.value, tag: tag}
+	sl.visit(d.value, "")
+}
+
+// visit the nested structs first, then call the hook of the struct rv.
+// returns true on should stop.
+func (sl *structLevel) visit(rv reflect.Value, path string) bool {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		// mirror parseRulesFromTag: skip unexported fields, sub-struct cascade.
+		if !sf.IsExported() && !gOpt.ValidatePrivateFields {
+			continue
+		}
+		if _, ok := sf.Tag.Lookup(sl.tag); !ok && !sf.Anonymous && gOpt.CheckSubOnParentMarked {
+			continue
+		}
+		if !hasStructLevel(sf.Type) {
+			continue
+		}
+
+		name := sf.Name
+		if path != "" {
+			name = path + "." + name
+		}
+
+		fv := reflectx.RemoveValuePtr(rv.Field(i))
+		switch fv.Kind() {
+		case reflect.Struct:
+			if sl.visit(fv, name) {
+				return true
+			}
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < fv.Len(); j++ {
+				if sl.visitElem(fv.Index(j), name+"."+strconv.Itoa(j)) {
+					return true
+				}
+			}
+		case reflect.Map:
+			for _, key := range sortedMapKeys(fv) {
+				if sl.visitElem(fv.MapIndex(key), name+"."+fmt.Sprint(key.Interface())) {
+					return true
+				}
+			}
+		}
+	}
+
+	return sl.call(rv, path)
+}
+
+func (sl *structLevel) visitElem(ev reflect.Value, path string) bool {
+	ev = reflectx.RemoveValuePtr(indirectInterface(ev))
+	if ev.Kind() != reflect.Struct || ev.Type() == timeType {
+		return false
+	}
+	return sl.visit(ev, path)
+}
+
+// call the ValidateStruct hook of the struct rv, skip it if the fields of the
+// struct have errors. returns true on should stop.
+func (sl *structLevel) call(rv reflect.Value, path string) bool {
+	if sl.v.ctxDone() {
+		return true
+	}
+	if !rv.CanInterface() || sl.fieldsFailed(path) {
+		return false
+	}
+
+	var face StructLevelFace
+	if rv.CanAddr() {
+		face, _ = rv.Addr().Interface().(StructLevelFace)
+	}
+	if face == nil {
+		if face, _ = rv.Interface().(StructLevelFace); face == nil {
+			return false
+		}
+	}
+
+	sl.cur, sl.path = rv, path
+	if err := face.ValidateStruct(sl); err != nil {
+		sl.AddError("", validateError, err.Error())
+	}
+	return sl.v.shouldStop()
+}
+
+// fieldsFailed check the struct at path or its fields have errors.
+func (sl *structLevel) fieldsFailed(path string) bool {
+	for _, fe := range sl.v.FieldErrors() {
+		if path == "" || fe.Field == path || strings.HasPrefix(fe.Field, path+".") {
+			return true
+		}
+	}
+	return false
+}
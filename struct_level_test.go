@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type slItem struct {
+	Name  string `validate:"required"`
+	Qty   int    `validate:"min:1"`
+	Price int
+}
+
+func (it *slItem) ValidateStruct(sl StructLevel) error {
+	if it.Qty > 10 && it.Price > 100 {
+		sl.AddError("Qty", "maxQty", "too many expensive items")
+	}
+	return nil
+}
+
+type slOrder struct {
+	ID    string   `validate:"required"`
+	Total int      `validate:"required"`
+	Items []slItem `validate:"slice"`
+	Ship  slAddr   `validate:"required"`
+}
+
+func (o slOrder) ValidateStruct(sl StructLevel) error {
+	sum := 0
+	for _, it := range o.Items {
+		sum += it.Qty * it.Price
+	}
+	if sum != o.Total {
+		sl.AddError("Total", "sum", "total must be equal to the sum of items")
+	}
+	return nil
+}
+
+type slAddr struct {
+	City string `validate:"required"`
+	Zip  string
+}
+
+func (a slAddr) ValidateStruct(_ StructLevel) error {
+	if a.City == "none" {
+		return errors.New("invalid address")
+	}
+	return nil
+}
+
+func TestStructLevel(t *testing.T) {
+	is := assert.New(t)
+
+	is.True(getTypeMeta(reflect.TypeOf(slOrder{})).hasStructLevel)
+	is.False(getTypeMeta(reflect.TypeOf(checkUser{})).hasStructLevel)
+
+	o := &slOrder{ID: "1", Total: 30, Items: []slItem{{Name: "a", Qty: 1, Price: 10}, {Name: "b", Qty: 2, Price: 10}}, Ship: slAddr{City: "x"}}
+	v := Struct(o)
+	is.True(v.Validate())
+
+	// top-level error
+	o.Total = 40
+	v = Struct(o)
+	is.False(v.Validate())
+	is.Eq("total must be equal to the sum of items", v.Errors.FieldOne("Total"))
+	is.Eq("sum", v.FieldErrors()[0].Validator)
+
+	// nested struct returns error, slice element adds error. nested run first
+	o = &slOrder{ID: "1", Total: 1200, Items: []slItem{{Name: "a", Qty: 11, Price: 101}, {Name: "b", Qty: 1, Price: 89}}, Ship: slAddr{City: "none"}}
+	v = Struct(o)
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Eq("too many expensive items", v.Errors.FieldOne("Items.0.Qty"))
+	is.Eq("invalid address", v.Errors.FieldOne("Ship"))
+	is.Len(v.FieldErrors(), 2)
+	is.Eq("Items.0.Qty", v.FieldErrors()[0].Field)
+
+	// stop on the first error
+	v = Struct(o)
+	is.False(v.Validate())
+	is.Len(v.Errors, 1)
+	is.Eq("Items.0.Qty", v.FieldErrors()[0].Field)
+
+	// skip the hook of a struct whose field rules failed
+	o = &slOrder{ID: "1", Total: 5, Items: []slItem{{Name: "", Qty: 11, Price: 101}}, Ship: slAddr{City: "x"}}
+	v = Struct(o)
+	v.StopOnError = false
+	is.False(v.Validate())
+	is.Len(v.FieldErrors(), 1)
+	is.Eq("Items.0.Name", v.FieldErrors()[0].Field)
+
+	// by Check
+	r := Check(&slOrder{ID: "1", Total: 2, Ship: slAddr{City: "x"}})
+	is.True(r.Fail())
+	is.Eq("total must be equal to the sum of items", r.One())
+}
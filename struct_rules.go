@@ -28,10 +28,13 @@ type ruleTemplate struct {
 	optionals   map[string]int8
 	defValues   map[string]any
 	fieldNames  map[string]int8
+	// the bad rules config errors on CollectConfigErr is true. read-only.
+	configErrs ConfigErrors
 
 	// translation tables (relative to a fresh Translator):
 	labelMap map[string]string // trans.labelMap
 	fieldMap map[string]string // trans.fieldMap (output names)
+	errKeys  map[string]string // v.errKeys (full output paths on ErrKeyOutputName)
 	messages map[string]string // ONLY custom messages added during collection
 }
 
@@ -133,14 +136,23 @@ func buildRuleTemplate(rt reflect.Type) *ruleTemplate {
 
 	td.parseRulesFromTag(tv)
 
+	tpl := newRuleTemplate(tv)
+	tpl.fieldNames = td.fieldNames
+	return tpl
+}
+
+// newRuleTemplate snapshot the rules, filters and translations collected on tv,
+// and pre-convert the rule args. tv must not be used after.
+func newRuleTemplate(tv *Validation) *ruleTemplate {
 	tpl := &ruleTemplate{
 		rules:       tv.rules,
 		filterRules: tv.filterRules,
 		optionals:   tv.optionals,
 		defValues:   tv.defValues,
-		fieldNames:  td.fieldNames,
+		configErrs:  tv.configErrs,
 		labelMap:    tv.trans.labelMap,
 		fieldMap:    tv.trans.fieldMap,
+		errKeys:     tv.errKeys,
 	}
 
 	// keep only custom messages (those differing from the builtin defaults),
@@ -215,7 +227,17 @@ func (d *StructData) instantiateStatic(v *Validation) {
 	}
 
 	tpl := d.meta.staticTemplate()
+	tpl.applyTo(v)
+
+	// --- field names (TryGet/Set rely on these) ---
+	for k, val := range tpl.fieldNames {
+		d.fieldNames[k] = val
+	}
+}
 
+// applyTo clones the template rules, filters, default values and translations
+// into v. It is shared by instantiateStatic and RuleSet.
+func (tpl *ruleTemplate) applyTo(v *Validation) {
 	// --- rules: clone each rule with its OWN args slice ---
 	// convertArgsType (validating.go) mutates r.arguments in place at validate
 	// time (string->typed). Sharing the template's args slice would corrupt the
@@ -270,9 +292,9 @@ func (d *StructData) instantiateStatic(v *Validation) {
 		v.SetDefValue(k, val)
 	}
 
-	// --- field names (TryGet/Set rely on these) ---
-	for k, val := range tpl.fieldNames {
-		d.fieldNames[k] = val
+	// --- config errors: the ConfigError items are immutable, share them ---
+	if len(tpl.configErrs) > 0 {
+		v.configErrs = append(v.configErrs, tpl.configErrs...)
 	}
 
 	// --- translation tables: replay via the public helpers ---
@@ -285,6 +307,8 @@ func (d *StructData) instantiateStatic(v *Validation) {
 	for key, msg := range tpl.messages {
 		v.trans.AddMessage(key, msg)
 	}
+	// read-only, share it
+	v.errKeys = tpl.errKeys
 }
 
 // cloneRule makes a shallow copy of an immutable template Rule.
@@ -335,6 +359,13 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 	}
 
 	fOutMap := make(map[string]string)
+	// full output paths of the fields as the error keys, for ErrKeyOutputName.
+	// eg: "Address.zip_code". the fOutMap is still used for the labels.
+	var outPaths map[string]string
+	if gOpt.ErrKeyFmt == ErrKeyOutputName {
+		outPaths = make(map[string]string)
+	}
+
 	var recursiveFunc func(vv reflect.Value, vt reflect.Type, preStrName string, parentIsAnonymous bool)
 
 	vv := d.value
@@ -365,12 +396,28 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 			// validate rule. use Lookup to distinguish "no tag" from "empty tag":
 			// an empty `validate:""` still marks the field for sub-struct cascade.
 			vRule, hasVRuleTag := fv.Tag.Lookup(d.ValidateTag)
+			fRule := fv.Tag.Get(d.FilterTag)
+
+			// expand the rule macros, the macro filters run before the field filters.
+			// for a STATIC type it runs once on the rule template build.
+			// eg: `validate:"@username|notIn:admin"`
+			if hasRuleMacro(vRule) {
+				var macroFilter string
+				vRule, macroFilter = v.expandRuleMacros(name, vRule)
+				fRule = joinFilterRule(macroFilter, fRule)
+			}
 			if vRule != "" {
-				v.StringRule(name, vRule)
+				v.stringRule(name, vRule) // the macros are expanded
+			}
+
+			// field expression. eg: `expr:"EndAt > StartAt + duration(1h)"`
+			if gOpt.ExprTag != "" {
+				if src := fv.Tag.Get(gOpt.ExprTag); src != "" {
+					v.addEvalExpr(name, RuleEvalExpr, src)
+				}
 			}
 
 			// filter rule
-			fRule := fv.Tag.Get(d.FilterTag)
 			if fRule != "" {
 				v.FilterRule(name, fRule)
 			}
@@ -382,6 +429,26 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 				outName = strings.SplitN(outName, ",", 2)[0]
 			}
 
+			if outPaths != nil {
+				// use full output path, the field without tag use the field name.
+				// anonymous struct without tag is inlined, same as encoding/json.
+				outPath := outName
+				switch {
+				case outPath == "" && fv.Anonymous:
+					// inlined to the parent
+				case outPath == "" || outPath == "-":
+					outPath = fv.Name
+				}
+				if parentFName != "" {
+					if pOutPath := outPaths[parentFName]; pOutPath != "" {
+						outPath = joinOutPath(pOutPath, outPath)
+					}
+				}
+				if outPath != "" {
+					outPaths[name] = outPath
+				}
+			}
+
 			// add pre field display name to fName
 			if outName != "" {
 				if parentFName != "" {
@@ -448,6 +515,9 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 						if outName != "" {
 							fOutMap[arrayName] = fmt.Sprintf("%s.%d", outName, j)
 						}
+						if outPath := outPaths[name]; outPath != "" {
+							outPaths[arrayName] = fmt.Sprintf("%s.%d", outPath, j)
+						}
 						if elemType.Kind() == reflect.Struct {
 							recursiveFunc(elemValue, elemType, arrayName, fv.Anonymous)
 						}
@@ -485,6 +555,9 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 						if outName != "" {
 							fOutMap[arrayName] = fmt.Sprintf(format, outName, val)
 						}
+						if outPath := outPaths[name]; outPath != "" {
+							outPaths[arrayName] = fmt.Sprintf(format, outPath, val)
+						}
 						if elemType.Kind() == reflect.Struct {
 							recursiveFunc(elemValue, elemType, arrayName, fv.Anonymous)
 						}
@@ -516,6 +589,9 @@ func (d *StructData) parseRulesFromTag(v *Validation) {
 	if len(fOutMap) > 0 {
 		v.Trans().AddFieldMap(fOutMap)
 	}
+	if len(outPaths) > 0 {
+		v.errKeys = outPaths
+	}
 }
 
 // eg: `message:"required:name is required|minLen:name min len is %d"`
@@ -543,6 +619,12 @@ func (d *StructData) loadMessagesFromTag(trans *Translator, field, vRule, vMsg s
 			}
 
 			for i, node := range vNames {
+				node = strings.TrimSpace(node)
+				// boolean expression: "(email or isCnMobile)"
+				if isRuleExpr(node) {
+					vNames[i] = RuleExprName
+					continue
+				}
 				// has params for validator: "minLen:5"
 				if strings.ContainsRune(node, ':') {
 					tmp := strings.SplitN(node, ":", 2)
@@ -575,3 +657,11 @@ func (d *StructData) loadMessagesFromTag(trans *Translator, field, vRule, vMsg s
 		trans.AddMessage(msgKey, strings.TrimSpace(nodes[1]))
 	}
 }
+
+// joinOutPath join the parent output path and the field output name.
+func joinOutPath(parent, name string) string {
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
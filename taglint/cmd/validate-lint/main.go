@@ -0,0 +1,14 @@
+// Command validate-lint checks the validate struct tags, see the taglint package.
+//
+// Usage:
+//
+//	validate-lint ./...
+//	go vet -vettool=$(which validate-lint) ./...
+package main
+
+import (
+	"github.com/gookit/validate/v2/taglint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(taglint.Analyzer) }
@@ -0,0 +1,18 @@
+module github.com/gookit/validate/v2/taglint
+
+go 1.22.0
+
+require (
+	github.com/gookit/validate/v2 v2.0.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/gookit/filter v1.2.3 // indirect
+	github.com/gookit/goutil v0.8.0 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
+)
+
+replace github.com/gookit/validate/v2 => ../
@@ -0,0 +1,471 @@
+// Package taglint provides a go/analysis analyzer to check the validate
+// struct tags, the mistakes are reported before the runtime panics.
+//
+// It reports:
+//   - unknown validator names, the wrong number of the rule args
+//   - bad dive rules or boolean expressions
+//   - eqField, requiredIf ... referencing a field that does not exist
+//   - the message tag naming a validator not in the rule
+//   - the scenes (WithScenes in ConfigValidation) referencing unknown fields
+//
+// The custom validators registered by AddValidator, AddValidators or
+// AddAsyncValidator are known, also the ones registered in the imported
+// packages, eg: in the init func. The struct method validators are known too.
+//
+// Usage with go vet:
+//
+//	go install github.com/gookit/validate/v2/taglint/cmd/validate-lint@latest
+//	go vet -vettool=$(which validate-lint) ./...
+package taglint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/validate/v2"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const pkgPath = "github.com/gookit/validate/v2"
+
+// Analyzer checks the validate struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name:      "validatetag",
+	Doc:       "check the validate struct tags: unknown validators, args number, field references, messages and scenes",
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(validatorsFact)},
+}
+
+var (
+	// the tag names, same as the GlobalOption
+	validateTag = "validate"
+	messageTag  = "message"
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&validateTag, "tag", validateTag, "the validate tag name")
+	Analyzer.Flags.StringVar(&messageTag, "message-tag", messageTag, "the message tag name")
+}
+
+// argNum the number of the rule args accepted by a validator. max < 0 is no limit.
+type argNum struct {
+	Min, Max int
+}
+
+func (an argNum) String() string {
+	switch {
+	case an.Max < 0:
+		return fmt.Sprintf("at least %d", an.Min)
+	case an.Min == an.Max:
+		return strconv.Itoa(an.Min)
+	}
+	return fmt.Sprintf("%d-%d", an.Min, an.Max)
+}
+
+// validatorsFact the custom validators registered by a package.
+type validatorsFact struct {
+	Validators map[string]argNum
+}
+
+// AFact implements analysis.Fact
+func (*validatorsFact) AFact() {}
+
+func (f *validatorsFact) String() string {
+	names := make([]string, 0, len(f.Validators))
+	for name := range f.Validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "validators(" + strings.Join(names, ", ") + ")"
+}
+
+// the validators with the field name args. value is the index of the first
+// non-field arg, -1 for all args are fields.
+var fieldArgValidators = map[string]int{
+	"eqField":            1,
+	"neField":            1,
+	"gtField":            1,
+	"gteField":           1,
+	"ltField":            1,
+	"lteField":           1,
+	"requiredIf":         1,
+	"requiredUnless":     1,
+	"requiredWith":       -1,
+	"requiredWithAll":    -1,
+	"requiredWithout":    -1,
+	"requiredWithoutAll": -1,
+}
+
+type checker struct {
+	pass *analysis.Pass
+	// custom validators of the package and the imported packages
+	custom map[string]argNum
+	// ConfigValidation methods of the struct types. key is the type name
+	configFns map[string]*ast.FuncDecl
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{
+		pass:      pass,
+		custom:    make(map[string]argNum),
+		configFns: make(map[string]*ast.FuncDecl),
+	}
+
+	for _, pf := range pass.AllPackageFacts() {
+		if f, ok := pf.Fact.(*validatorsFact); ok {
+			for name, an := range f.Validators {
+				c.custom[name] = an
+			}
+		}
+	}
+
+	local := make(map[string]argNum)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil), (*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			c.collectValidators(n, local)
+		case *ast.FuncDecl:
+			if n.Recv != nil && n.Name.Name == "ConfigValidation" {
+				c.configFns[recvTypeName(n.Recv.List[0].Type)] = n
+			}
+		}
+	})
+
+	if len(local) > 0 {
+		pass.ExportPackageFact(&validatorsFact{Validators: local})
+		for name, an := range local {
+			c.custom[name] = an
+		}
+	}
+
+	ins.Preorder([]ast.Node{(*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		ts := n.(*ast.TypeSpec)
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return
+		}
+
+		obj := pass.TypesInfo.Defs[ts.Name]
+		if obj == nil {
+			return
+		}
+		c.checkStruct(obj.Type(), st)
+		if fn, ok := c.configFns[ts.Name.Name]; ok {
+			c.checkScenes(obj.Type(), fn)
+		}
+	})
+	return nil, nil
+}
+
+// collectValidators collect the validators registered by the call.
+func (c *checker) collectValidators(call *ast.CallExpr, local map[string]argNum) {
+	fn, ok := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || len(call.Args) == 0 {
+		return
+	}
+
+	switch fn.Name() {
+	case "AddValidator":
+		if name, ok := c.constString(call.Args[0]); ok && len(call.Args) > 1 {
+			local[name] = c.funcArgNum(call.Args[1])
+		}
+	case "AddAsyncValidator":
+		if name, ok := c.constString(call.Args[0]); ok {
+			local[name] = argNum{Min: 0, Max: -1}
+		}
+	case "AddValidators":
+		lit, ok := ast.Unparen(call.Args[0]).(*ast.CompositeLit)
+		if !ok {
+			return
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if name, ok := c.constString(kv.Key); ok {
+					local[name] = c.funcArgNum(kv.Value)
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) constString(expr ast.Expr) (string, bool) {
+	tv, ok := c.pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// funcArgNum the args number of a validator func expr. unknown is no limit.
+func (c *checker) funcArgNum(expr ast.Expr) argNum {
+	sig, ok := c.pass.TypesInfo.TypeOf(expr).(*types.Signature)
+	if !ok {
+		return argNum{Min: 0, Max: -1}
+	}
+	return sigArgNum(sig)
+}
+
+// sigArgNum the rule args number of a validator func, exclude the value arg.
+// same as the runtime checkArgNum.
+func sigArgNum(sig *types.Signature) argNum {
+	params := sig.Params()
+	if params.Len() == 0 {
+		return argNum{}
+	}
+
+	// func(FieldCtx) bool: the args get by FieldCtx.Arg
+	if isValidateType(params.At(params.Len()-1).Type(), "FieldCtx") {
+		return argNum{Min: 0, Max: -1}
+	}
+
+	n := params.Len() - 1
+	if isValidateType(params.At(0).Type(), "DataFace") {
+		n--
+	}
+	if sig.Variadic() {
+		return argNum{Min: n - 1, Max: -1}
+	}
+	return argNum{Min: n, Max: n}
+}
+
+func isValidateType(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+func (c *checker) checkStruct(typ types.Type, st *ast.StructType) {
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+
+		raw, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tags := reflect.StructTag(raw)
+		rule, ok := tags.Lookup(validateTag)
+		if !ok {
+			continue
+		}
+
+		pos := tagPos(f.Tag, validateTag)
+		items, err := validate.ParseTagRule(rule)
+		if err != nil {
+			c.pass.Reportf(pos, "invalid rule %q: %s", rule, err)
+			continue
+		}
+
+		for _, item := range items {
+			c.checkItem(typ, pos, item)
+		}
+
+		if msg, ok := tags.Lookup(messageTag); ok {
+			c.checkMessage(tagPos(f.Tag, messageTag), msg, items)
+		}
+	}
+}
+
+func (c *checker) checkItem(typ types.Type, pos token.Pos, item validate.TagRule) {
+	an, ok := c.validatorArgs(typ, item)
+	if !ok {
+		c.pass.Reportf(pos, "unknown validator %q", item.Validator)
+		return
+	}
+
+	if got := len(item.Args); got < an.Min || (an.Max >= 0 && got > an.Max) {
+		c.pass.Reportf(pos, "validator %q wants %s args, got %d", item.Validator, an, got)
+		return
+	}
+
+	end, ok := fieldArgValidators[item.Name]
+	if !ok || item.Elem {
+		return
+	}
+	if end < 0 || end > len(item.Args) {
+		end = len(item.Args)
+	}
+	for _, arg := range item.Args[:end] {
+		if field, ok := arg.(string); ok && !hasField(typ, field) {
+			c.pass.Reportf(pos, "validator %q: field %q not found in %s", item.Validator, field, typeName(typ))
+		}
+	}
+}
+
+// validatorArgs find the validator: custom, struct method, the global validators.
+func (c *checker) validatorArgs(typ types.Type, item validate.TagRule) (argNum, bool) {
+	for _, name := range []string{item.Validator, item.Name} {
+		if an, ok := c.custom[name]; ok {
+			return an, true
+		}
+	}
+
+	if minNum, maxNum, ok := validate.ValidatorArgs(item.Name); ok {
+		return argNum{Min: minNum, Max: maxNum}, true
+	}
+
+	// struct method validator. see StructData.FuncValue
+	methodName := strings.ToUpper(item.Name[:1]) + item.Name[1:]
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, c.pass.Pkg, methodName)
+	if fn, ok := obj.(*types.Func); ok {
+		return sigArgNum(fn.Type().(*types.Signature)), true
+	}
+	return argNum{}, false
+}
+
+// checkMessage check the validators in the message tag, same format as StringMessage.
+func (c *checker) checkMessage(pos token.Pos, msg string, items []validate.TagRule) {
+	for _, seg := range strings.Split(msg, "|") {
+		name, text, ok := strings.Cut(strings.TrimSpace(seg), ":")
+		if !ok {
+			continue
+		}
+		if name = strings.TrimSpace(name); name == "" || strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		found := false
+		for _, item := range items {
+			if item.Validator == name || item.Name == validate.ValidatorName(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.pass.Reportf(pos, "message for validator %q, but it is not in the rule", name)
+		}
+	}
+}
+
+// checkScenes check the fields of the scenes in ConfigValidation. eg:
+//
+//	v.WithScenes(validate.SValues{"add": {"Name", "Age"}})
+func (c *checker) checkScenes(typ types.Type, fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "WithScenes" && sel.Sel.Name != "WithScenarios") {
+			return true
+		}
+		lit, ok := ast.Unparen(call.Args[0]).(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			scene, _ := c.constString(kv.Key)
+			fields, ok := kv.Value.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+
+			for _, fe := range fields.Elts {
+				if field, ok := c.constString(fe); ok && !hasField(typ, field) {
+					c.pass.Reportf(fe.Pos(), "scene %q: field %q not found in %s", scene, field, typeName(typ))
+				}
+			}
+		}
+		return true
+	})
+}
+
+// hasField check the field path exists in the struct type. eg: "Name", "Addr.City", "Items.*.Sku"
+func hasField(typ types.Type, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		typ = elemType(typ)
+		if name == "*" || isIndex(name) {
+			switch t := typ.Underlying().(type) {
+			case *types.Slice:
+				typ = t.Elem()
+			case *types.Array:
+				typ = t.Elem()
+			case *types.Map:
+				typ = t.Elem()
+			default:
+				return false
+			}
+			continue
+		}
+
+		// map value: any key is allowed
+		if m, ok := typ.Underlying().(*types.Map); ok {
+			typ = m.Elem()
+			continue
+		}
+
+		obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, name)
+		v, ok := obj.(*types.Var)
+		if !ok || !v.IsField() {
+			return false
+		}
+		typ = v.Type()
+	}
+	return true
+}
+
+func elemType(typ types.Type) types.Type {
+	for {
+		p, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			return typ
+		}
+		typ = p.Elem()
+	}
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func typeName(typ types.Type) string {
+	if named, ok := typ.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return typ.String()
+}
+
+func recvTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// tagPos the position of the tag key in the struct tag literal.
+func tagPos(lit *ast.BasicLit, key string) token.Pos {
+	if i := strings.Index(lit.Value, key+`:"`); i >= 0 {
+		return lit.Pos() + token.Pos(i)
+	}
+	return lit.Pos()
+}
@@ -0,0 +1,16 @@
+package taglint_test
+
+import (
+	"testing"
+
+	"github.com/gookit/validate/v2/taglint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), taglint.Analyzer, "a")
+}
+
+func TestAnalyzer_custom(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), taglint.Analyzer, "custom")
+}
@@ -0,0 +1,52 @@
+package a // want package:`validators\(unique\)`
+
+import (
+	"time"
+
+	_ "custom"
+
+	"github.com/gookit/validate/v2"
+)
+
+func init() {
+	validate.AddAsyncValidator("unique", nil, time.Second)
+}
+
+type Address struct {
+	City string `validate:"required"`
+}
+
+type User struct {
+	Name     string            `validate:"required|minLen:3"`
+	Bad      string            `validate:"required|notExists"` // want `unknown validator "notExists"`
+	Age      int               `validate:"between:1"`          // want `validator "between" wants 2 args, got 1`
+	Email    string            `validate:"email:x"`            // want `validator "email" wants 0 args, got 1`
+	Sku      string            `validate:"isSku|prefix:ab|fctx:1,2|unique"`
+	Sku2     string            `validate:"prefix"` // want `validator "prefix" wants 1 args, got 0`
+	Pass     string            `validate:"required"`
+	Pass2    string            `validate:"eqField:Pass"`
+	Pass3    string            `validate:"eqField:Password"` // want `validator "eqField": field "Password" not found in User`
+	City     string            `validate:"requiredIf:Addr.City,x"`
+	Zip      string            `validate:"requiredWith:Addr.Zip"` // want `validator "requiredWith": field "Addr.Zip" not found in User`
+	Addr     Address           `validate:"required"`
+	Tags     []string          `validate:"dive|minLen:2|isUpper"` // want `unknown validator "isUpper"`
+	Phone    string            `validate:"(email or isCnMobile)"`
+	Phone2   string            `validate:"(email or )"` // want `invalid rule`
+	Labels   map[string]string `validate:"dive|keys|alpha|endkeys|required"`
+	Nick     string            `validate:"required|minLen:2" message:"required:nick is required|max:too long"` // want `message for validator "max", but it is not in the rule`
+	Nick2    string            `validate:"required|min_len:2" message:"minLen:too short"`
+	Code     string            `validate:"checkCode:3"`
+	Code2    string            `validate:"checkCode"` // want `validator "checkCode" wants 1 args, got 0`
+	Enum     string            `validate:"in:a,b,c"`
+	Regex    string            `validate:"regexp:^\\d{4,6}$"`
+	internal string
+}
+
+func (u User) CheckCode(val string, n int) bool { return len(val) == n }
+
+func (u User) ConfigValidation(v *validate.Validation) {
+	v.WithScenes(validate.SValues{
+		"add":    {"Name", "Addr.City"},
+		"update": {"Name", "Nickname"}, // want `scene "update": field "Nickname" not found in User`
+	})
+}
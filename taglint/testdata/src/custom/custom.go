@@ -0,0 +1,18 @@
+package custom // want package:`validators\(fctx, isSku, prefix\)`
+
+import "github.com/gookit/validate/v2"
+
+func init() {
+	validate.AddValidator("isSku", func(val string) bool { return val != "" })
+	validate.AddValidators(validate.M{
+		"prefix": func(val string, prefix string) bool { return true },
+		"fctx":   func(fc validate.FieldCtx) bool { return true },
+	})
+}
+
+// the validators registered in the same package are known
+type Item struct {
+	Sku  string `validate:"required|isSku|prefix:ab|fctx"`
+	Code string `validate:"prefix"` // want `validator "prefix" wants 1 args, got 0`
+	Name string `validate:"isSkuX"` // want `unknown validator "isSkuX"`
+}
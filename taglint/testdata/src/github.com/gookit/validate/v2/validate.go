@@ -0,0 +1,26 @@
+// Package validate is a stub of the validate package for the analyzer tests.
+package validate
+
+import "time"
+
+type M map[string]any
+
+type SValues map[string][]string
+
+type DataFace interface{ Get(key string) (any, bool) }
+
+type FieldCtx interface{ Arg(i int) any }
+
+type AsyncValidator interface{}
+
+type Validation struct{}
+
+func (v *Validation) WithScenes(scenes map[string][]string) *Validation { return v }
+
+func (v *Validation) AddValidator(name string, checkFunc any) *Validation { return v }
+
+func AddValidator(name string, checkFunc any) {}
+
+func AddValidators(m map[string]any) {}
+
+func AddAsyncValidator(name string, av AsyncValidator, timeout time.Duration) {}
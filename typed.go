@@ -0,0 +1,281 @@
+package validate
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Validator is a typed validator of the struct type T. it is built once per
+// struct type, the cached type metadata and rule template are resolved up front,
+// and the validation instances are reused from the package pool(see Check).
+//
+// The T must be a struct type, NewValidator returns an error otherwise.
+// Safe for concurrent use.
+//
+// Usage:
+//
+//	var userValidator = validate.MustValidator(validate.NewValidator[User]())
+//
+//	r := userValidator.Check(&user)
+//	err := userValidator.Validate(user)
+//	user, err := userValidator.Bind(map[string]any{"name": "tom"})
+type Validator[T any] struct {
+	f     *Factory
+	rt    reflect.Type
+	scene string
+}
+
+// NewValidator create a typed validator of the struct type T.
+// returns the error wraps ErrInvalidData if the T is not a struct type.
+func NewValidator[T any](scene ...string) (*Validator[T], error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.Struct || rt == timeType {
+		return nil, fmt.Errorf("%w: NewValidator the type %s is not a struct", ErrInvalidData, rt.String())
+	}
+
+	tv := &Validator[T]{f: defaultFactory, rt: rt}
+	if len(scene) > 0 {
+		tv.scene = scene[0]
+	}
+
+	// resolve the type metadata and rule template up front.
+	if meta := getTypeMeta(rt); meta.isStatic {
+		meta.staticTemplate()
+	}
+	return tv, nil
+}
+
+// MustValidator returns the Validator, panic on error.
+func MustValidator[T any](tv *Validator[T], err error) *Validator[T] {
+	if err != nil {
+		panic(err)
+	}
+	return tv
+}
+
+// Type returns the struct type of the validator.
+func (tv *Validator[T]) Type() reflect.Type { return tv.rt }
+
+// Check validate the struct, the default and filtered values are written back
+// to the ptr. same as validate.Check
+func (tv *Validator[T]) Check(ptr *T) *ValidResult {
+	return tv.f.Struct(ptr, tv.scene).ValidateR()
+}
+
+// Validate the struct value, returns the first error if failed, otherwise nil.
+// same as validate.CheckErr, val is validated on a copy, use Check to write
+// back the values.
+func (tv *Validator[T]) Validate(val T) error {
+	return tv.validate(&val)
+}
+
+// Bind the map data to a new T, then validate it. returns the first error if
+// validate failed, same as Validate.
+//
+// The data is set to the fields by the JSON names, same as the JSON decoding.
+// The values can not be set directly, eg: a string to time.Time, are converted
+// by the Marshal/Unmarshal hooks.
+func (tv *Validator[T]) Bind(data map[string]any) (T, error) {
+	var val T
+	if err := bindStruct(reflect.ValueOf(&val).Elem(), data); err != nil {
+		return val, err
+	}
+
+	err := tv.validate(&val)
+	return val, err
+}
+
+func (tv *Validator[T]) validate(ptr *T) error {
+	v := tv.f.Struct(ptr, tv.scene)
+	v.skipCollect = true // not need the safe data
+	v.Validate()
+	err := v.firstError()
+	v.Release()
+	return err
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// bindStruct set the map data to the struct fields by the JSON names, the
+// names are matched case-insensitively, same as the JSON decoding.
+func bindStruct(rv reflect.Value, data map[string]any) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" || !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		fv := rv.Field(i)
+		// the embedded struct fields are at the same level
+		if sf.Anonymous && name == "" {
+			if ft := removeTypePtr(sf.Type); ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Ptr {
+					if !sf.IsExported() {
+						continue
+					}
+					if fv.IsNil() {
+						fv.Set(reflect.New(ft))
+					}
+					fv = fv.Elem()
+				}
+				if err := bindStruct(fv, data); err != nil {
+					return err
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		val, ok := data[name]
+		if !ok {
+			for key, kv := range data {
+				if strings.EqualFold(key, name) {
+					val, ok = kv, true
+					break
+				}
+			}
+		}
+		if ok {
+			if err := bindValue(fv, val); err != nil {
+				return fmt.Errorf("bind the field '%s': %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bindValue set the value to rv. the nil value is skipped, same as the JSON null.
+func bindValue(rv reflect.Value, val any) error {
+	if val == nil {
+		return nil
+	}
+
+	// the custom decoding. eg: time.Time
+	if pt := reflect.PointerTo(rv.Type()); pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType) {
+		return bindByJSON(rv, val)
+	}
+
+	src := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return bindValue(rv.Elem(), val)
+	case reflect.Struct:
+		if m, ok := val.(map[string]any); ok {
+			return bindStruct(rv, m)
+		}
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice && !src.Type().AssignableTo(rv.Type()) {
+			sv := reflect.MakeSlice(rv.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := bindValue(sv.Index(i), src.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			rv.Set(sv)
+			return nil
+		}
+	case reflect.Map:
+		if src.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && !src.Type().AssignableTo(rv.Type()) {
+			mv := reflect.MakeMapWithSize(rv.Type(), src.Len())
+			iter := src.MapRange()
+			for iter.Next() {
+				ev := reflect.New(rv.Type().Elem()).Elem()
+				if err := bindValue(ev, iter.Value().Interface()); err != nil {
+					return err
+				}
+				mv.SetMapIndex(reflect.ValueOf(fmt.Sprint(iter.Key().Interface())).Convert(rv.Type().Key()), ev)
+			}
+			rv.Set(mv)
+			return nil
+		}
+	case reflect.Interface:
+		if src.Type().AssignableTo(rv.Type()) {
+			rv.Set(src)
+			return nil
+		}
+	}
+
+	switch {
+	case src.Type().AssignableTo(rv.Type()):
+		rv.Set(src)
+	case isNumberKind(src.Kind()) && isNumberKind(rv.Kind()) && numberFits(src, rv.Type()):
+		rv.Set(src.Convert(rv.Type()))
+	case src.Kind() == reflect.String && rv.Kind() == reflect.String,
+		src.Kind() == reflect.Bool && rv.Kind() == reflect.Bool:
+		rv.Set(src.Convert(rv.Type()))
+	default:
+		return bindByJSON(rv, val)
+	}
+	return nil
+}
+
+// bindByJSON convert the value by the Marshal/Unmarshal hooks.
+func bindByJSON(rv reflect.Value, val any) error {
+	bts, err := Marshal(val)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(bts, rv.Addr().Interface())
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64 && k != reflect.Uintptr
+}
+
+// numberFits reports whether the number can be converted to the type without
+// losing the value, same as the JSON decoding.
+func numberFits(src reflect.Value, typ reflect.Type) bool {
+	var f float64
+	switch {
+	case src.CanInt():
+		f = float64(src.Int())
+	case src.CanUint():
+		f = float64(src.Uint())
+	default:
+		f = src.Float()
+	}
+
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) {
+			return false
+		}
+		if src.CanInt() {
+			return !reflect.Zero(typ).OverflowInt(src.Int())
+		}
+		if src.CanUint() {
+			return src.Uint() <= math.MaxInt64 && !reflect.Zero(typ).OverflowInt(int64(src.Uint()))
+		}
+		return f >= math.MinInt64 && f < math.MaxInt64 && !reflect.Zero(typ).OverflowInt(int64(f))
+	default: // uint
+		if f != math.Trunc(f) || f < 0 {
+			return false
+		}
+		if src.CanUint() {
+			return !reflect.Zero(typ).OverflowUint(src.Uint())
+		}
+		if src.CanInt() {
+			return !reflect.Zero(typ).OverflowUint(uint64(src.Int()))
+		}
+		return f < math.MaxUint64 && !reflect.Zero(typ).OverflowUint(uint64(f))
+	}
+}
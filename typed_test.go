@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/x/assert"
+)
+
+type typedUser struct {
+	Name  string `json:"name" validate:"required|minLen:3"`
+	Age   int    `json:"age" validate:"required|min:1"`
+	Email string `json:"email" validate:"email" filter:"trim|lower"`
+}
+
+func TestValidator(t *testing.T) {
+	is := assert.New(t)
+
+	uv, err := NewValidator[typedUser]()
+	is.NoErr(err)
+	is.Eq("typedUser", uv.Type().Name())
+
+	// Check: write back the filtered value
+	u := &typedUser{Name: "tom", Age: 20, Email: " TOM@Example.com "}
+	r := uv.Check(u)
+	is.True(r.IsOK())
+	is.Eq("tom@example.com", u.Email)
+
+	r = uv.Check(&typedUser{Name: "ab"})
+	is.True(r.Fail())
+
+	// Validate
+	is.NoErr(uv.Validate(typedUser{Name: "tom", Age: 20}))
+	err = uv.Validate(typedUser{Name: "ab", Age: 20})
+	is.Err(err)
+	is.StrContains(err.Error(), "name")
+
+	// Bind
+	u2, err := uv.Bind(map[string]any{"name": "jerry", "age": 3, "email": " A@B.com"})
+	is.NoErr(err)
+	is.Eq("jerry", u2.Name)
+	is.Eq(3, u2.Age)
+	is.Eq("a@b.com", u2.Email)
+
+	u2, err = uv.Bind(map[string]any{"name": "jerry"})
+	is.Err(err)
+	is.Eq("jerry", u2.Name)
+	_, err = uv.Bind(map[string]any{"age": "not-int"})
+	is.Err(err)
+
+	// scene
+	sv := MustValidator(NewValidator[typedUser]("none"))
+	is.NoErr(sv.Validate(typedUser{Name: "tom", Age: 1}))
+
+	// not a struct
+	_, err = NewValidator[string]()
+	is.True(errors.Is(err, ErrInvalidData))
+	_, err = NewValidator[*typedUser]()
+	is.ErrMsgContains(err, "is not a struct")
+	is.Panics(func() { MustValidator(NewValidator[int]()) })
+}
+
+type typedOrder struct {
+	ID      int64             `json:"id" validate:"required"`
+	Price   float64           `json:"price"`
+	Tags    []string          `json:"tags"`
+	User    *typedUser        `json:"user" validate:"required"`
+	Items   []typedUser       `json:"items"`
+	Extra   map[string]string `json:"extra"`
+	Created time.Time         `json:"created"`
+	Skip    string            `json:"-"`
+}
+
+func TestValidator_Bind(t *testing.T) {
+	is := assert.New(t)
+	ov := MustValidator(NewValidator[typedOrder]())
+
+	o, err := ov.Bind(map[string]any{
+		"ID":      float64(12),
+		"price":   3,
+		"tags":    []any{"a", "b"},
+		"user":    map[string]any{"name": "tom", "age": 2},
+		"items":   []any{map[string]any{"name": "inhere"}},
+		"extra":   map[string]any{"k": "v"},
+		"created": "2024-01-02T03:04:05Z",
+		"Skip":    "x",
+	})
+	is.NoErr(err)
+	is.Eq(int64(12), o.ID)
+	is.Eq(float64(3), o.Price)
+	is.Eq([]string{"a", "b"}, o.Tags)
+	is.Eq("tom", o.User.Name)
+	is.Eq("inhere", o.Items[0].Name)
+	is.Eq("v", o.Extra["k"])
+	is.Eq(2024, o.Created.Year())
+	is.Empty(o.Skip)
+
+	// bind error
+	_, err = ov.Bind(map[string]any{"id": 1.5})
+	is.ErrMsgContains(err, "'id'")
+	_, err = ov.Bind(map[string]any{"created": "invalid"})
+	is.Err(err)
+
+	// the validate error
+	_, err = ov.Bind(map[string]any{"id": 1})
+	is.ErrMsgContains(err, "user")
+}
+
+func TestValidator_configError(t *testing.T) {
+	is := assert.New(t)
+	uv := MustValidator(NewValidator[typedUser]())
+
+	// the invalid input error is not lost
+	var u *typedUser
+	is.ErrMsgContains(uv.validate(u), "invalid input data")
+}
+
+func TestValidator_concurrent(t *testing.T) {
+	uv := MustValidator(NewValidator[typedUser]())
+	done := make(chan bool)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			for j := 0; j < 50; j++ {
+				err := uv.Validate(typedUser{Name: "tom", Age: i + 1})
+				assert.NoErr(t, err)
+			}
+			done <- true
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
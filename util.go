@@ -239,26 +239,35 @@ func panicf(format string, args ...any) {
 // checkValidatorFunc 校验自定义校验器函数签名(至少 1 个入参、唯一返回值为 bool)。
 // R3: func(FieldCtx) bool 形态天然满足这些约束(NumIn==1、NumOut==1 且 bool),无需额外分支。
 func checkValidatorFunc(name string, fn any) reflect.Value {
+	fv, err := validatorFunc(name, fn)
+	if err != nil {
+		panicf("%s", err.Error())
+	}
+	return fv
+}
+
+// validatorFunc is like checkValidatorFunc, but returns error on invalid.
+func validatorFunc(name string, fn any) (reflect.Value, error) {
 	if !goodName(name) {
-		panicf("validate name %s is not a valid identifier", name)
+		return emptyValue, fmt.Errorf("validate name %s is not a valid identifier", name)
 	}
 
 	fv := reflect.ValueOf(fn)
 	if fn == nil || fv.Kind() != reflect.Func { // is nil or not is func
-		panicf("validator '%s'. 2th parameter is invalid, it must be an func", name)
+		return emptyValue, fmt.Errorf("validator '%s'. 2th parameter is invalid, it must be an func", name)
 	}
 
 	ft := fv.Type()
 	if ft.NumIn() == 0 {
-		panicf("validator '%s' func at least one parameter position", name)
+		return emptyValue, fmt.Errorf("validator '%s' func at least one parameter position", name)
 	}
 
 	// TODO support return error as validate error.
 	if ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.Bool {
-		panicf("validator '%s' func must be return a bool value", name)
+		return emptyValue, fmt.Errorf("validator '%s' func must be return a bool value", name)
 	}
 
-	return fv
+	return fv, nil
 }
 
 func checkFilterFunc(name string, fn any) reflect.Value {
@@ -462,6 +471,18 @@ func removeTypePtr(t reflect.Type) reflect.Type {
 	return t
 }
 
+// structTypeOf get the type from a struct value, pointer or reflect.Type.
+// ok is false if it is not a struct type.
+func structTypeOf(s any) (rt reflect.Type, ok bool) {
+	if rt, ok = s.(reflect.Type); !ok && s != nil {
+		rt = reflect.TypeOf(s)
+	}
+	if rt != nil {
+		rt = removeTypePtr(rt)
+	}
+	return rt, rt != nil && rt.Kind() == reflect.Struct
+}
+
 // ---- From package "text/template" -> text/template/exec.go
 
 // indirect returns the item at the end of indirection, and a bool to indicate if it's nil.
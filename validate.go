@@ -28,12 +28,16 @@ type MS map[string]string
 // SValues simple values
 type SValues map[string][]string
 
-// One get one item's value string
+// One get the value string of the first key, by the sorted keys
 func (ms MS) One() string {
-	for _, msg := range ms {
-		return msg
+	var first string
+	var found bool
+	for key := range ms {
+		if !found || key < first {
+			first, found = key, true
+		}
 	}
-	return ""
+	return ms[first]
 }
 
 // String convert map[string]string to string
@@ -43,9 +47,9 @@ func (ms MS) String() string {
 	}
 
 	ss := make([]string, 0, len(ms))
-	for name, msg := range ms {
+	ms.OrderedRange(func(name, msg string) {
 		ss = append(ss, " "+name+": "+msg)
-	}
+	})
 
 	return strings.Join(ss, "\n")
 }
@@ -89,12 +93,21 @@ type GlobalOption struct {
 	//
 	// default: message
 	MessageTag string
+	// ExprTag define the field expression, same as the "expr" validator.
+	// It is disabled by default, the tag may be used by other libraries.
+	// see RuleEvalExpr
+	//
+	// eg: "expr"
+	ExprTag string
 	// DefaultTag define default value for the field.
 	//
 	// tag: default TODO
 	DefaultTag string
 	// StopOnError If true: An error occurs, it will cease to continue to verify. default is True.
 	StopOnError bool
+	// AsyncLimit the max number of the async validators run concurrently.
+	// <= 0 is no limit. default is 8. see AddAsyncValidator
+	AsyncLimit int
 	// SkipOnEmpty Skip check on field not exist or value is empty. default is True.
 	SkipOnEmpty bool
 	// UpdateSource Whether to update source field value, useful for struct validate
@@ -106,6 +119,12 @@ type GlobalOption struct {
 	// byte-for-byte unchanged). When true, the failing value is appended in the
 	// form " (value: <val>)". see GitHub issue #184.
 	ErrShowValue bool
+	// CollectConfigErr If true: the bad rule config is collected as ConfigError,
+	// instead of panic. eg: unknown validator, args number mismatch, invalid
+	// dive rules. default is false.
+	//
+	// The errors are returned by Validation.Err() and ValidateErr(), errors.Is(err, ErrConfig) is true.
+	CollectConfigErr bool
 	// CheckZero whether to validate the zero value. (intX,uintX: 0, string: "")
 	//
 	// Deprecated: this flag is a no-op — it was declared but never wired into the
@@ -114,11 +133,13 @@ type GlobalOption struct {
 	// Validation/global option, or mark the field as required. It will be removed
 	// in a future release.
 	CheckZero bool
-	// ErrKeyFmt config. TODO
+	// ErrKeyFmt the format of the Errors key for struct validation.
 	//
 	// allow:
-	// - 0 use struct field name as key. (for compatible)
-	// - 1 use FieldTag defined name as key.
+	// - ErrKeyFieldName(0) use struct field name as key, the field has FieldTag
+	//   use the tag name. eg: "Address.ZipCode" (for compatible)
+	// - ErrKeyOutputName(1) use the full output path by FieldTag as key, same as
+	//   the JSON payload. eg: "address.zip_code", "items.0.sku"
 	ErrKeyFmt int8
 	// CheckSubOnParentMarked controls sub-struct (struct / *struct / slice-of-struct /
 	// map-of-struct) cascade validation.
@@ -144,6 +165,14 @@ type GlobalOption struct {
 	RestoreRequestBody bool
 }
 
+// the GlobalOption.ErrKeyFmt values
+const (
+	// ErrKeyFieldName use struct field name as error key. (default)
+	ErrKeyFieldName int8 = iota
+	// ErrKeyOutputName use the full output path by FieldTag as error key.
+	ErrKeyOutputName
+)
+
 // global options
 var gOpt = newGlobalOption()
 
@@ -171,6 +200,7 @@ func newGlobalOption() *GlobalOption {
 	return &GlobalOption{
 		StopOnError: true,
 		SkipOnEmpty: true,
+		AsyncLimit:  8,
 		// tag name in struct tags
 		FieldTag: fieldTag,
 		// label tag - display name in struct tags
@@ -233,6 +263,14 @@ func init() {
 	ctxValidatorBuilders["rule_one_of"] = func(v *Validation) reflect.Value {
 		return reflect.ValueOf(v.RuleOneOf)
 	}
+	// boolean rule expression, args[0] is the compiled expression.
+	ctxValidatorBuilders[RuleExprName] = func(v *Validation) reflect.Value {
+		return reflect.ValueOf(v.RuleExpr)
+	}
+	// field expression, args[0] is the compiled expression.
+	ctxValidatorBuilders[RuleEvalExpr] = func(v *Validation) reflect.Value {
+		return reflect.ValueOf(v.EvalExpr)
+	}
 }
 
 func newEmpty() *Validation {
@@ -248,8 +286,11 @@ func newEmpty() *Validation {
 		trans: NewTranslator(),
 		// default config
 		StopOnError:  gOpt.StopOnError,
+		AsyncLimit:   gOpt.AsyncLimit,
 		SkipOnEmpty:  gOpt.SkipOnEmpty,
 		ErrShowValue: gOpt.ErrShowValue,
+		// collect bad rule config errors
+		CollectConfigErr: gOpt.CollectConfigErr,
 	}
 
 	return v
@@ -389,8 +430,11 @@ func (d *StructData) fromStruct(s any) error {
 	}
 
 	val := reflects.Elem(reflect.ValueOf(s))
-	typ := val.Type()
+	if !val.IsValid() { // nil pointer
+		return ErrInvalidData
+	}
 
+	typ := val.Type()
 	if val.Kind() != reflect.Struct || typ == timeType {
 		return ErrInvalidData
 	}
@@ -514,21 +558,30 @@ var defaultFactory = NewFactory()
 // the safe-data write-back semantics it relies on are struct-only — hence Check /
 // CheckErr do not accept map/form sources.
 //
+// If structPtr implements GeneratedValidator (see cmd/validate-gen), the
+// generated code is used instead.
+//
 //	r := validate.Check(&user)
 //	if r.Fail() { return r.Err() }
 //	r.BindSafeData(&out)
 func Check(structPtr any, scene ...string) *ValidResult {
+	if gv, tm := generatedOf(structPtr, scene); gv != nil {
+		return runGenerated(gv, tm, true).result(structPtr)
+	}
 	return defaultFactory.Struct(structPtr, scene...).ValidateR()
 }
 
 // CheckErr is the opt-in FAST pass/fail entry for a STRUCT: it returns only an
-// error (nil = passed; otherwise a random field error via Errors.OneError).
+// error (nil = passed; otherwise the first failed field error).
 //
 // Like Check it is pooled, but it additionally SKIPS collecting safe/filtered
 // data and SKIPS building a *ValidResult — so it allocates the least of all
 // entries. Use it for hot "accept or reject" paths (e.g. middleware) where the
 // cleaned data and BindStruct are not needed.
 //
+// If structPtr implements GeneratedValidator (see cmd/validate-gen), the
+// generated code is used instead.
+//
 // When you need the cleaned data or struct binding, use Check / ValidateR
 // instead. CheckErr is STRUCT-ONLY by design: its skip-collect fast path relies
 // on struct source value write-back (UpdateSource) for cross-field correctness,
@@ -538,10 +591,14 @@ func Check(structPtr any, scene ...string) *ValidResult {
 //		return err
 //	}
 func CheckErr(structPtr any, scene ...string) error {
+	if gv, tm := generatedOf(structPtr, scene); gv != nil {
+		return runGenerated(gv, tm, false).firstError()
+	}
+
 	v := defaultFactory.Struct(structPtr, scene...)
 	v.skipCollect = true // must precede Validate so applyField skips collection
 	v.Validate()
-	err := v.Errors.OneError()
+	err := v.firstError()
 	v.Release()
 	return err
 }
@@ -174,6 +174,67 @@ func TestOption(t *testing.T) {
 	ResetOption()
 }
 
+type errKeyAddr struct {
+	ZipCode string `json:"zip_code" validate:"required"`
+	City    string `validate:"required"`
+}
+
+type errKeyBase struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type errKeyItem struct {
+	SKU string `json:"sku" validate:"required"`
+}
+
+type errKeyUser struct {
+	errKeyBase `validate:""`
+	Address    errKeyAddr   `validate:""`
+	Items      []errKeyItem `json:"items" validate:""`
+	Tags       []string     `json:"tags" validate:"dive|minLen:2"`
+}
+
+func TestOption_ErrKeyFmt(t *testing.T) {
+	is := assert.New(t)
+	u := &errKeyUser{Items: []errKeyItem{{}}, Tags: []string{"a"}}
+
+	newV := func() *Validation {
+		v := Struct(u)
+		v.StopOnError = false
+		return v
+	}
+
+	Config(func(opt *GlobalOption) { opt.ValidatePrivateFields = true })
+	defer ResetOption()
+
+	// default: struct field name, or FieldTag name of the field
+	v := newV()
+	is.False(v.Validate())
+	is.Eq([]string{"Address.City", "code", "items.0.sku", "tags.0", "zip_code"}, v.Errors.Fields())
+
+	Config(func(opt *GlobalOption) { opt.ErrKeyFmt = ErrKeyOutputName })
+	v = newV()
+	is.False(v.Validate())
+	is.Eq([]string{"Address.City", "Address.zip_code", "code", "items.0.sku", "tags.0"}, v.Errors.Fields())
+	// only the error key is changed, the label is same as default
+	is.Eq("zip_code is required to not be empty", v.Errors.FieldOne("Address.zip_code"))
+	is.Eq("Address.City is required to not be empty", v.Errors.FieldOne("Address.City"))
+
+	// the static type use the rule template
+	type addrOnly struct {
+		Address errKeyAddr `validate:""`
+	}
+	for i := 0; i < 2; i++ {
+		es := Struct(&addrOnly{}).ValidateE()
+		is.Eq("zip_code is required to not be empty", es.FieldOne("Address.zip_code"))
+	}
+
+	r := newV().ValidateR()
+	is.Eq("code", r.FieldErrors()[0].Name)
+	is.Eq("errKeyBase.Code", r.FieldErrors()[0].Field)
+	is.True(r.Errors.HasField("items.0.sku"))
+}
+
 func TestStruct_nilPtr_field2(t *testing.T) {
 	type UserDto struct {
 		Name string `validate:"required"`
@@ -65,10 +65,16 @@ func (v *Validation) ValidateData(data DataFace) bool {
 }
 
 // ValidateErr do validate processing and return error
+//
+// If has bad rules config, returns the ConfigErrors (wraps ErrConfig) instead
+// of the Errors. see CollectConfigErr
 func (v *Validation) ValidateErr(scene ...string) error {
 	if v.Validate(scene...) {
 		return nil
 	}
+	if v.configErrs != nil {
+		return v.configErrs
+	}
 	return v.Errors
 }
 
@@ -103,12 +109,16 @@ func (v *Validation) ValidateR(scene ...string) *ValidResult {
 	// move (not copy) the result out of v into the standalone result object.
 	r := &ValidResult{
 		Errors:       v.Errors,
+		fieldErrors:  v.fieldErrs.items,
+		ctxErr:       v.ctxErr,
+		configErr:    v.Err(),
 		safeData:     v.safeData,
 		filteredData: v.filteredData,
 	}
 	// hand over ownership: nil the moved maps on v so Release()'s clear() leaves
 	// them alone and the lazy-alloc chain rebuilds cleanly on the next reuse.
 	v.Errors = nil
+	v.fieldErrs.reset()
 	v.safeData = nil
 	v.filteredData = nil
 	v.Release() // no-op unless v came from a pool (Factory / Check)
@@ -135,6 +145,19 @@ func (v *Validation) Validate(scene ...string) bool {
 	v.SetScene(scene...)
 	v.sceneFields = v.sceneFieldMap()
 
+	// has bad rules config, report them and stop. see CollectConfigErr
+	if v.configErrs != nil {
+		v.addConfigErrors()
+		v.hasValidated = true
+		return false
+	}
+
+	// check all rules before validating, the rules of an empty field are
+	// skipped on validating.
+	if v.CollectConfigErr {
+		v.precompileRules()
+	}
+
 	// apply filter rules before validate.
 	if !v.Filtering() && v.StopOnError {
 		return false
@@ -147,6 +170,21 @@ func (v *Validation) Validate(scene ...string) bool {
 		}
 	}
 
+	// the bad rules found on validating. eg: unknown validator
+	if v.configErrs != nil {
+		v.addConfigErrors()
+	}
+
+	// run the queued async validators, after the sync rules.
+	if len(v.asyncJobs) > 0 && !v.shouldStop() {
+		v.runAsync()
+	}
+
+	// struct-level validation, after all field rules.
+	if sd, ok := v.data.(*StructData); ok && !v.shouldStop() {
+		sd.validateStructLevel(v)
+	}
+
 	v.hasValidated = true
 	if v.hasError && !v.skipCollect { // clear safe data on error (skip in CheckErr fast path).
 		v.safeData = make(map[string]any)
@@ -171,7 +209,7 @@ func (r *Rule) Apply(v *Validation) (stop bool) {
 
 	// validate each field
 	for _, field := range r.fields {
-		if r.applyField(field, name, v) {
+		if v.ctxDone() || r.applyField(field, name, v) {
 			return true
 		}
 	}
@@ -283,6 +321,14 @@ func (r *Rule) applyField(field, name string, v *Validation) (stop bool) {
 		return false
 	}
 
+	// validate each element of the field value.
+	if name == RuleDive {
+		if r.diveValidate(field, fv, v) {
+			v.commitValue(field, fv)
+		}
+		return v.shouldStop()
+	}
+
 	// validate field value
 	if r.valueValidate(field, name, fv, v) {
 		if v.data != nil && v.data.Type() == sourceForm {
@@ -296,7 +342,19 @@ func (r *Rule) applyField(field, name string, v *Validation) (stop bool) {
 		if v.ErrShowValue {
 			msg = fmt.Sprintf("%s (value: %v)", msg, fv.Src())
 		}
-		v.AddError(field, r.validator, msg)
+		fe := FieldError{
+			Field:     field,
+			Validator: name,
+			Alias:     r.validator,
+			Args:      r.errorArgs(),
+			Value:     fv.Src(),
+			Message:   msg,
+		}
+		if v.wcFailed != "" {
+			fe.Path = v.outputPath(v.wcFailed)
+			v.wcFailed = ""
+		}
+		v.addFieldError(fe)
 	}
 
 	if v.shouldStop() {
@@ -329,7 +387,8 @@ func (r *Rule) fileValidate(field, name string, v *Validation) uint8 {
 		ok = v.IsFormImage(form, field, ss...)
 	case "inMimeTypes":
 		if ln := len(ss); ln == 0 {
-			panicf("not enough parameters for validator '%s'!", r.validator)
+			v.configErrorf(field, r.validator, "not enough parameters for validator '%s'!", r.validator)
+			return statusSkip
 		} else if ln == 1 {
 			//noinspection GoNilness
 			ok = v.InMimeTypes(form, field, ss[0])
@@ -400,8 +459,27 @@ func (r *Rule) valueValidate(field, name string, fv *fieldval.FieldValue, v *Val
 		// fallback: get validator from global or validation
 		fm = v.validatorMeta(name)
 		if fm == nil {
-			panicf("the validator '%s' does not exist", r.validator)
+			// on CollectConfigErr, skip the rule and report it as config error.
+			v.configErrorf(field, r.validator, "the validator '%s' does not exist", r.validator)
+			return true
+		}
+	}
+
+	// async validator: queue it, run concurrently after the sync rules. see runAsync
+	if fm.style == styleAsync {
+		// the expression result can not wait for the async result.
+		if r.inExpr {
+			v.configErrorf(field, r.validator, "the async validator '%s' can not be used in the rule expression", r.validator)
+			return true
+		}
+
+		// queue each element of the ".*" wildcard slice
+		if rv := fv.RV(); dotStarNum > 0 && rv.Kind() == reflect.Slice {
+			r.queueAsyncElems(fm, field, rv, dotStarNum, v)
+		} else {
+			r.queueAsync(fm, field, "", fv.Src(), v)
 		}
+		return true
 	}
 
 	// 1. args number check
@@ -415,17 +493,21 @@ func (r *Rule) valueValidate(field, name string, fv *fieldval.FieldValue, v *Val
 		valArgKind = ft.In(1).Kind()
 	}
 
-	// R3: fieldctx 风格校验器签名固定为 func(FieldCtx)bool, 规则 args 经 fc.Arg() 取,
+	// R3: fieldctx 风格校验器签名固定为 func(FieldCtx)bool(或带 ctx 的
+	// func(context.Context, FieldCtx)bool), 规则 args 经 fc.Arg() 取,
 	// 不作为函数形参 → 跳过 checkArgNum(否则 argNum!=numIn=1 panic)与 convertArgsType
 	// (否则会按 In(0)=接口错误转换);值类型转换块因 valArgKind 恒为 Interface 已天然跳过。
-	isFieldCtx := fm.style == styleFieldCtx
+	isFieldCtx := fm.style != styleLegacy
 
 	// some prepare and check.
 	argNum := len(r.arguments) + addNum // "data" and "val" position
 	// check arg num is match, need exclude "requiredXXX"
 	if r.nameNotRequired && !isFieldCtx {
 		//noinspection GoNilness
-		fm.checkArgNum(argNum, r.validator)
+		if err := fm.checkArgNum(argNum, r.validator); err != nil {
+			v.configErrorf(field, r.validator, "%s", err.Error())
+			return true
+		}
 	}
 
 	// 2. args data type convert. Skip when the static template already
@@ -481,8 +563,7 @@ func (r *Rule) valueValidate(field, name string, fv *fieldval.FieldValue, v *Val
 
 // validateWildcardSlice validates the ".*" wildcard slice branch: it flattens
 // multi-level slices, handles the requiredXX empty-slice / map parent-length
-// cases, then converts and validates each element. Logic is平移 unchanged from
-// the original inline branch.
+// cases, then converts and validates each element walked by eachElem.
 //
 // rftVal is the slice reflect.Value (fv.RV()); slice sub-elements never match
 // the top-level carrier, so callValidator is always invoked with vfv=nil.
@@ -513,52 +594,85 @@ func (r *Rule) validateWildcardSlice(fm *funcMeta, field string, rftVal reflect.
 		}
 	}
 
-	var subVal any
+	// the element paths for the FieldCtx, resolved once per rule.
+	var paths []string
+	if fm.style != styleLegacy {
+		paths = wildcardElemPaths(field, rftVal, v)
+	}
+
 	// check each element in the slice.
-	for i := 0; i < sliceLen; i++ {
-		subRv := indirectInterface(rftVal.Index(i))
+	return eachElem(rftVal, func(i int, subRv reflect.Value) bool {
+		var subVal any
 		subKind := subRv.Kind()
 
-		// T5: 自定义类型 → 提取每个元素的底层值,再走下面的类型转换/校验逻辑。
-		// 门控内联:未注册时不进入提取分支,保证元素循环零开销。提取后用提取值的
-		// reflect.Value 重置 subRv/subKind;提取为 nil 时 subRv 变为 invalid,直接
-		// 当作 nil 处理,避免对 invalid Value 调用 Interface() 触发 panic。
-		if hasCustomTypes.Load() && subRv.IsValid() {
-			if ev, ok := resolveCustomType(subRv.Interface()); ok {
-				if ev == nil {
-					subVal = nil
-					if !callValidator(v, fm, field, subVal, r.arguments, addNum, nil) {
-						return false
-					}
-					continue
-				}
-				subRv = reflect.ValueOf(ev)
-				subKind = subRv.Kind()
-			}
-		}
-
 		// 1.1 convert field value type, is func first argument.
-		if r.nameNotRequired && valArgKind != reflect.Interface && valArgKind != subKind {
+		if subRv.IsValid() && r.nameNotRequired && valArgKind != reflect.Interface && valArgKind != subKind {
+			var ok bool
 			subVal, ok = convValAsFuncValArgType(valArgKind, subKind, subRv.Interface())
 			if !ok {
 				v.convArgTypeError(field, fm.name, subKind, valArgKind, 1)
 				return false
 			}
-		} else {
-			if subRv.IsValid() {
-				subVal = subRv.Interface()
-			} else {
-				subVal = nil
-			}
+		} else if subRv.IsValid() {
+			subVal = subRv.Interface()
 		}
 
 		// 2. call built in validator. subVal is a slice element, not the
 		// top-level value, so it gets no carrier (vfv=nil).
-		if !callValidator(v, fm, field, subVal, r.arguments, addNum, nil) {
-			return false
+		if paths != nil {
+			v.wcPath = paths[i]
+		}
+		ok := callValidator(v, fm, field, subVal, r.arguments, addNum, nil)
+		v.wcPath = ""
+		if !ok {
+			// record the failed element path, the error is added by the caller.
+			if paths == nil {
+				paths = wildcardElemPaths(field, rftVal, v)
+			}
+			if paths != nil {
+				v.wcFailed = paths[i]
+			}
 		}
+		return ok
+	})
+}
+
+// wildcardElemPaths returns the concrete path of each element of the flattened
+// ".*" wildcard slice. eg: "Items.*.Qty" -> ["Items.0.Qty", "Items.1.Qty"]
+//
+// Returns nil if the paths are not aligned with the elements.
+func wildcardElemPaths(field string, rv reflect.Value, v *Validation) []string {
+	if v.data == nil {
+		return nil
+	}
+
+	paths := wildcardPaths(dataRootValue(v.data), field)
+	if len(paths) != rv.Len() {
+		return nil
 	}
+	return paths
+}
+
+// eachElem call fn with each element of the slice or array, stop on fn returns
+// false. The element interface and the registered custom type are resolved,
+// the nil element is an invalid value. It is shared by the ".*" wildcard and
+// the "dive" elements.
+func eachElem(rv reflect.Value, fn func(i int, ev reflect.Value) bool) bool {
+	for i := 0; i < rv.Len(); i++ {
+		ev := indirectInterface(rv.Index(i))
+
+		// T5: 自定义类型 → 提取每个元素的底层值。门控内联:未注册时不进入提取分支,
+		// 保证元素循环零开销。提取为 nil 时 ev 变为 invalid, 当作 nil 处理。
+		if hasCustomTypes.Load() && ev.IsValid() {
+			if val, ok := resolveCustomType(ev.Interface()); ok {
+				ev = reflect.ValueOf(val)
+			}
+		}
 
+		if !fn(i, ev) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -656,6 +770,18 @@ func callValidator(v *Validation, fm *funcMeta, field string, val any, args []an
 		}
 	case "rule_one_of": // #292: 列表参数同 enum, args[0] 为子校验器名 []string
 		ok = v.RuleOneOf(boxedVal(val, vfv), args[0])
+	case RuleExprName: // args[0] is the compiled boolean rule expression
+		c := vfv
+		if c == nil {
+			c = fieldval.New(field, val)
+		}
+		ok = v.evalRuleExpr(field, c, args[0])
+	case RuleEvalExpr: // args[0] is the compiled field expression
+		c := vfv
+		if c == nil {
+			c = fieldval.New(field, val)
+		}
+		ok = v.evalFieldExpr(field, c, args[0])
 	case "notIn":
 		if vfv != nil {
 			ok = ivalidators.NotIn(vfv, args[0])
@@ -1047,13 +1173,13 @@ func convertRuleArgs(fm *funcMeta, field string, args []any, addNum int) error {
 // `val` differs from the carrier's source (e.g. after type conversion or for
 // slice sub-elements), so the reflect.Value stays consistent with `val`.
 func callValidatorValue(v *Validation, fm *funcMeta, field string, val any, args []any, addNum int, vfv *fieldval.FieldValue) bool {
-	// R3: fieldctx 风格 → typed 直调,免 reflect.Call 的 argIn 装箱。args 经 fc.Arg() 取。
+	// R3: fieldctx/ctx 风格 → typed 直调,免 reflect.Call 的 argIn 装箱。args 经 fc.Arg() 取。
 	//
 	// 注意(逃逸): 绝不把入参 vfv 指针存进会逃逸到堆的 fieldCtx,否则 escape 分析会把
 	// vfv 形参标记为 leaking,连带让 legacy 热路径在 valueValidate 构造的 carrier 也
 	// 逃逸到堆(实测 +5 allocs)。这里改为按 vfv 的(值拷贝)reflect.Value 现造一个新
 	// carrier(NewRV 不重做 ValueOf),vfv 仅被读取不被存储,从而切断逃逸链。
-	if fm.style == styleFieldCtx {
+	if fm.style != styleLegacy {
 		var carrier *fieldval.FieldValue
 		if vfv != nil {
 			// 复用 vfv 已缓存的 RV(值拷贝传入),语义与 vfv.RealV()/Raw() 一致。
@@ -1061,7 +1187,11 @@ func callValidatorValue(v *Validation, fm *funcMeta, field string, val any, args
 		} else { // wildcard/转换值路径无载体,按 field+val 现造
 			carrier = fieldval.New(field, val)
 		}
-		return fm.fcFunc(&fieldCtx{fv: carrier, field: field, args: args})
+		fc := &fieldCtx{fv: carrier, field: field, args: args, v: v, path: v.wcPath}
+		if fm.style == styleCtx {
+			return fm.ctxFunc(v.Context(), fc)
+		}
+		return fm.fcFunc(fc)
 	}
 
 	// ===== legacy reflect 路径(与改造前逐字节一致,仅 fv 改用 fm.fv) =====
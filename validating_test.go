@@ -506,6 +506,8 @@ func TestRequired_MissingParentField(t *testing.T) {
 	assert.StrContains(t, s, "coding.*.details.cpt.*.not_exist_field is required")
 }
 
+// the optional parents are all checked, the not empty parent does not skip the
+// check of the empty sub parent.
 // TestRequiredByCtx_Parity asserts requiredByCtx (carrier RV-native) returns the
 // same result as Required (public IsEmpty(any)) across map & struct sources and
 // zero / non-zero values (RFC R4.2a §4). Both share the same *Validation state,
@@ -1,11 +1,13 @@
 package validate
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 	"sync"
 
+	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/validate/v2/internal/fieldval"
 )
 
@@ -20,6 +22,11 @@ const (
 
 	filterError   = "_filter"
 	validateError = "_validate"
+	contextError  = "_context"
+	configError   = "_config"
+	// the message keys of the async validator could not check the value
+	asyncError        = "_async"
+	asyncTimeoutError = "_asyncTimeout"
 
 	// sniff Length, use for detect file mime type
 	sniffLen = 512
@@ -57,10 +64,15 @@ type Validation struct {
 
 	// Errors for validate
 	Errors Errors
+	// the errors with details in the occurred order. see FieldErrors
+	fieldErrs fieldErrorList
 	// CacheKey for cache rules
 	// CacheKey string
 	// StopOnError If true: An error occurs, it will cease to continue to verify
 	StopOnError bool
+	// AsyncLimit the max number of the async validators run concurrently.
+	// <= 0 is no limit. see AddAsyncValidator
+	AsyncLimit int
 	// SkipOnEmpty Skip check on field not exist or value is empty
 	SkipOnEmpty bool
 	// UpdateSource Whether to update source field value, useful for struct validate
@@ -70,6 +82,9 @@ type Validation struct {
 	// ErrShowValue Whether to append the failing value to the error message.
 	// opt-in, copied from gOpt. see GitHub issue #184.
 	ErrShowValue bool
+	// CollectConfigErr If true: collect the bad rule config errors instead of
+	// panic. see Err(), Precompile()
+	CollectConfigErr bool
 	// CachingRules switch. default is False
 	// CachingRules bool
 
@@ -112,6 +127,9 @@ type Validation struct {
 	//
 	// key is field name, value is field vale is: init=0 empty=1 not-empty=2.
 	optionals map[string]int8
+	// errKeys the full output paths of the struct fields as the error keys,
+	// only on GlobalOption.ErrKeyFmt is ErrKeyOutputName. eg: "Address.zip_code"
+	errKeys map[string]string
 
 	// CheckErr(skipCollect) 模式状态。skipCollect=true 时跳过 safeData/filteredData
 	// 收集,改用 scKey/scVal 1 槽缓存对"同字段连续取值"做装箱去重(镜像 safeData 的
@@ -127,6 +145,20 @@ type Validation struct {
 	// *FieldValue 指针, 不会导致 getFieldCarrier 现造的载体逃逸到堆。
 	scRV   reflect.Value
 	scIsRV bool
+
+	// wcPath 为 ".*" 通配元素校验时当前元素的具体路径(""=非通配), 供 FieldCtx 使用。
+	wcPath string
+	// wcFailed 为 ".*" 通配元素校验失败时该元素的具体路径, 供 FieldError.Path 使用。
+	wcFailed string
+
+	// ctx the context for the validating, see ValidateCtx. nil = no context.
+	ctx context.Context
+	// ctxErr the error on the ctx is done, wraps ErrCanceled and the ctx.Err()
+	ctxErr error
+	// asyncJobs the queued async validates. see AddAsyncValidator
+	asyncJobs []*asyncJob
+	// configErrs the collected bad rule config errors. see CollectConfigErr
+	configErrs ConfigErrors
 }
 
 // NewEmpty new validation instance, but not with data.
@@ -221,6 +253,9 @@ func (v *Validation) ResetResult() {
 	// Step 2: result maps reset to nil (lazily re-allocated on first write), so a
 	// Reset()'d instance keeps the no-alloc property on the next clean validation.
 	v.Errors = nil
+	v.fieldErrs.reset()
+	v.ctxErr = nil
+	v.asyncJobs = nil
 	v.hasError = false
 	v.hasFiltered = false
 	v.hasValidated = false
@@ -248,6 +283,7 @@ func (v *Validation) resetRules() {
 	v.rules = v.rules[:0]
 	v.optionals = nil // lazily re-allocated on first write (ensureOptionals)
 	v.filterRules = v.filterRules[:0]
+	v.configErrs = nil
 }
 
 // resetForReuse fully resets ALL per-validation state back to the newEmpty()
@@ -272,6 +308,7 @@ func (v *Validation) resetForReuse() {
 	// --- result data + flags (mirrors ResetResult, but clears maps in place to
 	// reuse the already-allocated buckets — this is the whole point of pooling) ---
 	clear(v.Errors)
+	v.fieldErrs.reset()
 	v.hasError = false
 	v.hasFiltered = false
 	v.hasValidated = false
@@ -284,8 +321,10 @@ func (v *Validation) resetForReuse() {
 	// NOTE: Struct() sets UpdateSource=true after Create; CheckDefault may be
 	// toggled by callers. All must go back to the New-time initial values.
 	v.StopOnError = gOpt.StopOnError
+	v.AsyncLimit = gOpt.AsyncLimit
 	v.SkipOnEmpty = gOpt.SkipOnEmpty
 	v.ErrShowValue = gOpt.ErrShowValue
+	v.CollectConfigErr = gOpt.CollectConfigErr
 	v.UpdateSource = false
 	v.CheckDefault = false
 
@@ -293,6 +332,7 @@ func (v *Validation) resetForReuse() {
 	v.rules = v.rules[:0]
 	v.filterRules = v.filterRules[:0]
 	clear(v.optionals)
+	v.errKeys = nil
 
 	// --- validators: drop per-type custom validators + lazily-bound ctx metas.
 	// newEmpty() starts with empty maps; ctx validators rebind lazily to this
@@ -325,6 +365,13 @@ func (v *Validation) resetForReuse() {
 	v.scVal = nil
 	v.scRV = reflect.Value{}
 	v.scIsRV = false
+	v.wcPath = ""
+	v.wcFailed = ""
+	v.ctx = nil
+	v.ctxErr = nil
+	clear(v.asyncJobs)
+	v.asyncJobs = v.asyncJobs[:0]
+	v.configErrs = nil
 }
 
 // TODO Config(opt *Options) *Validation
@@ -400,7 +447,11 @@ func (v *Validation) AddValidators(m map[string]any) *Validation {
 //		return true
 //	})
 func (v *Validation) AddValidator(name string, checkFunc any) *Validation {
-	fv := checkValidatorFunc(name, checkFunc)
+	fv, err := validatorFunc(name, checkFunc)
+	if err != nil {
+		v.configErrorf("", name, "%s", err.Error())
+		return v
+	}
 
 	v.ensureValidatorMaps() // lazy
 	v.validators[name] = validatorTypeCustom
@@ -568,13 +619,110 @@ func (v *Validation) WithError(err error) *Validation {
 
 // AddError message for a field
 func (v *Validation) AddError(field, validator, msg string) {
+	v.addFieldError(FieldError{Field: field, Alias: validator, Message: msg})
+}
+
+// addFieldError collect the error. the empty output name, label and
+// real validator name are filled by the field and validator.
+func (v *Validation) addFieldError(fe FieldError) {
 	if !v.hasError {
 		v.hasError = true
 	}
 
+	if fe.Name == "" {
+		fe.Name = v.errKey(fe.Field)
+	}
+	if fe.Path == "" {
+		fe.Path = v.outputPath(fe.Field)
+	}
+	if fe.Label == "" {
+		fe.Label = v.trans.LabelName(fe.Field)
+	}
+	if fe.Validator == "" {
+		fe.Validator = ValidatorName(fe.Alias)
+	}
+
 	v.ensureErrors() // lazy: only the error path allocates Errors
-	field = v.trans.FieldName(field)
-	v.Errors.Add(field, validator, msg)
+	v.Errors.Add(fe.Name, fe.Alias, fe.Message)
+	v.fieldErrs.add(fe)
+}
+
+// errKey get the error key of the field, the full output path on ErrKeyOutputName.
+func (v *Validation) errKey(field string) string {
+	if key, ok := v.errKeys[field]; ok {
+		return key
+	}
+	return v.trans.FieldName(field)
+}
+
+// outputPath convert the struct field path to the output path by the field tag,
+// the anonymous struct without tag is inlined, same as encoding/json.
+// eg: "Address.ZipCode" -> "address.zip_code"
+func (v *Validation) outputPath(field string) string {
+	if sd, ok := v.data.(*StructData); ok {
+		return outputPathOf(sd.valueTyp, field)
+	}
+	return field
+}
+
+// outputPathOf convert the field path of the struct type rt to the output path.
+// see Validation.outputPath
+func outputPathOf(rt reflect.Type, field string) string {
+	if gOpt.FieldTag == "" || field == "" || field[0] == '_' {
+		return field
+	}
+
+	nodes := strings.Split(field, ".")
+	out := make([]string, 0, len(nodes))
+	for i, node := range nodes {
+		rt = removeTypePtr(rt)
+		switch rt.Kind() {
+		case reflect.Struct:
+			sf, ok := rt.FieldByName(node)
+			if !ok {
+				return strings.Join(append(out, nodes[i:]...), ".")
+			}
+
+			name, _, _ := strings.Cut(sf.Tag.Get(gOpt.FieldTag), ",")
+			switch {
+			case name == "" && sf.Anonymous: // inlined to the parent
+			case name == "" || name == "-":
+				out = append(out, sf.Name)
+			default:
+				out = append(out, name)
+			}
+			rt = sf.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			out = append(out, node)
+			rt = rt.Elem()
+		default:
+			return strings.Join(append(out, nodes[i:]...), ".")
+		}
+	}
+	return strings.Join(out, ".")
+}
+
+// FieldErrors returns all errors with the details, in the order they occurred.
+func (v *Validation) FieldErrors() []FieldError {
+	if len(v.fieldErrs.items) > 0 {
+		return v.fieldErrs.items
+	}
+	// the errors added to the Errors map directly
+	return v.Errors.List()
+}
+
+// firstError returns the first occurred error, nil if no error.
+func (v *Validation) firstError() error {
+	if v.configErrs != nil {
+		return v.configErrs
+	}
+	if v.ctxErr != nil {
+		return v.ctxErr
+	}
+	if fes := v.FieldErrors(); len(fes) > 0 {
+		return errorx.Raw(fes[0].Message)
+	}
+	return nil
 }
 
 // AddErrorf add a formatted error message
@@ -871,7 +1019,7 @@ func (v *Validation) IsSuccess() bool { return !v.hasError }
 
 // on stop on error
 func (v *Validation) shouldStop() bool {
-	return v.hasError && v.StopOnError
+	return v.hasError && (v.StopOnError || v.ctxErr != nil || v.configErrs != nil)
 }
 
 // check current field is in optional parent field.
@@ -1,6 +1,8 @@
 package validate
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
@@ -93,6 +95,8 @@ var (
 const (
 	styleLegacy   uint8 = iota // func(val any, ...) → reflect.Call
 	styleFieldCtx              // func(FieldCtx) bool → typed 直调
+	styleCtx                   // func(context.Context, FieldCtx) bool → typed 直调
+	styleAsync                 // AsyncValidator → 入队, 同步规则后并发执行
 )
 
 type funcMeta struct {
@@ -108,24 +112,27 @@ type funcMeta struct {
 	isVariadic bool
 	// R3: 自定义校验器签名形态。styleLegacy=func(val any,...)(reflect.Call);
 	// styleFieldCtx=func(FieldCtx)bool(typed 直调,免 reflect.Call)。
-	style  uint8
-	fcFunc func(FieldCtx) bool // 仅 style==styleFieldCtx 时非 nil
+	style   uint8
+	fcFunc  func(FieldCtx) bool                  // 仅 style==styleFieldCtx 时非 nil
+	ctxFunc func(context.Context, FieldCtx) bool // 仅 style==styleCtx 时非 nil
+	async   *asyncMeta                           // 仅 style==styleAsync 时非 nil
 }
 
-func (fm *funcMeta) checkArgNum(argNum int, name string) {
+func (fm *funcMeta) checkArgNum(argNum int, name string) error {
 	// last arg is like "... any"
 	if fm.isVariadic {
 		if argNum+1 < fm.numIn {
-			panicf("not enough parameters for validator '%s'!", name)
+			return fmt.Errorf("not enough parameters for validator '%s'!", name)
 		}
 	} else if argNum != fm.numIn {
-		panicf(
+		return fmt.Errorf(
 			"the number of parameters given does not match the required. validator '%s', want %d, given %d",
 			name,
 			fm.numIn,
 			argNum,
 		)
 	}
+	return nil
 }
 
 func newFuncMeta(name string, builtin bool, fv reflect.Value) *funcMeta {
@@ -138,9 +145,13 @@ func newFuncMeta(name string, builtin bool, fv reflect.Value) *funcMeta {
 
 	// R3: 识别 func(FieldCtx) bool 形态,存 typed 函数以便分派时直调(免 reflect.Call)。
 	// 对 builtin 也会跑,但 builtin 永不匹配,无害。
-	if fc, ok := fv.Interface().(func(FieldCtx) bool); ok {
+	switch fn := fv.Interface().(type) {
+	case func(FieldCtx) bool:
 		fm.style = styleFieldCtx
-		fm.fcFunc = fc
+		fm.fcFunc = fn
+	case func(context.Context, FieldCtx) bool:
+		fm.style = styleCtx
+		fm.ctxFunc = fn
 	}
 
 	return fm
@@ -237,18 +248,21 @@ func (v *Validation) requiredByCtx(field string, fv *fieldval.FieldValue) bool {
 //   - 子项名支持别名 (ip->isIP, cidr->isCIDR), 经 ValidatorName 解析后取 funcMeta。
 //   - phase1 仅支持无参子校验器, 故在原 val 上以 addNum=1 直接调用, 不传 field/args。
 //   - 未知子校验器名 → 直接 panic, 与现有"未知 validator"行为一致, 提前暴露拼写错误。
+//     CollectConfigErr=true 时改为收集 ConfigError, 本规则视为通过。
 func (v *Validation) RuleOneOf(val any, rules any) bool {
 	names, ok := rules.([]string)
 	if !ok || len(names) == 0 {
-		panicf("the validator 'rule_one_of' requires a non-empty list of validator names")
+		v.configErrorf("", "rule_one_of", "the validator 'rule_one_of' requires a non-empty list of validator names")
+		return true
 	}
 
 	for _, name := range names {
 		realName := ValidatorName(strings.TrimSpace(name))
 		fm := v.validatorMeta(realName)
 		if fm == nil {
-			// fail-fast: 子校验器不存在(含拼写错误), 立即 panic。
-			panicf("the validator '%s' for 'rule_one_of' does not exist", name)
+			// fail-fast: 子校验器不存在(含拼写错误), 立即 panic 或收集配置错误。
+			v.configErrorf("", "rule_one_of", "the validator '%s' for 'rule_one_of' does not exist", name)
+			return true
 		}
 
 		// 在同一个值上调用子校验器, 任一返回 true 即整体通过。
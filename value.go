@@ -56,22 +56,42 @@ func Val(val any, rule string) error {
 		// reset only what valueValidate may have dirtied; lazily-built
 		// validatorMetas stay bound to this instance and are reused.
 		if v.hasError {
-			v.Errors = make(Errors)
+			v.Errors = nil
+			v.fieldErrs.reset()
 			v.hasError = false
 		}
+		if len(v.asyncJobs) > 0 {
+			clear(v.asyncJobs)
+			v.asyncJobs = v.asyncJobs[:0]
+		}
 		valPool.Put(v)
 	}()
 
 	var r *Rule
 	var realName string
-	for _, validator := range rules {
+	for i, validator := range rules {
 		validator = strings.Trim(validator, ":")
 		if validator == "" {
 			continue
 		}
 
-		// validator has args. eg: "min:12"
-		if strings.ContainsRune(validator, ':') {
+		// the rest rules apply to each element. eg: "dive|email"
+		if validator == RuleDive {
+			r = buildRule(field, RuleDive, RuleDive, []any{mustParseDive(rules[i+1:])})
+			if !r.diveValidate(field, fv, v) {
+				return v.Errors.ErrOrNil()
+			}
+			break
+		}
+
+		// field expression on the value. eg: "expr:$ > 0 and $ % 2 == 0"
+		if name, src, ok := strings.Cut(validator, ":"); ok && ValidatorName(name) == RuleEvalExpr {
+			r = buildRule(field, name, RuleEvalExpr, []any{src})
+			validator, realName = name, RuleEvalExpr
+		} else if isRuleExpr(validator) { // boolean expression. eg: "email or isCnMobile"
+			r = buildRule(field, RuleExprName, RuleExprName, []any{mustParseRuleExpr(validator)})
+			validator, realName = RuleExprName, RuleExprName
+		} else if strings.ContainsRune(validator, ':') { // validator has args. eg: "min:12"
 			list := stringSplit(validator, ":")
 			// reassign value
 			validator = list[0]
@@ -102,6 +122,11 @@ func Val(val any, rule string) error {
 		}
 	}
 
+	// run the queued async validators
+	if len(es) == 0 && len(v.asyncJobs) > 0 {
+		v.runAsync()
+		return v.Errors.ErrOrNil()
+	}
 	return es.ErrOrNil()
 }
 